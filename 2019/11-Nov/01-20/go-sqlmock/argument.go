@@ -1,10 +1,22 @@
 package sqlmock
 
-import "database/sql/driver"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+)
 
 // Argument interface allows to match
 // any argument in specific way when used with
 // ExpectedQuery and ExpectedExec expectations.
+//
+// An Argument which also implements fmt.Stringer is
+// described by its String method in failure messages.
 type Argument interface {
 	Match(driver.Value) bool
 }
@@ -22,3 +34,252 @@ type anyArgument struct{}
 func (a anyArgument) Match(_ driver.Value) bool {
 	return true
 }
+
+func (a anyArgument) String() string {
+	return "AnyArg()"
+}
+
+// MatcherFunc type is an adapter to allow the use of
+// ordinary functions as Argument.
+type MatcherFunc func(driver.Value) bool
+
+// Match implements the Argument
+func (f MatcherFunc) Match(v driver.Value) bool {
+	return f(v)
+}
+
+// String returns string representation
+func (f MatcherFunc) String() string {
+	return "MatcherFunc"
+}
+
+// AnyOfType will return an Argument which matches any
+// argument of the same type as the driver value of sample,
+// so AnyOfType(0) matches any integer and AnyOfType(time.Time{})
+// any time.
+func AnyOfType(sample interface{}) Argument {
+	if v, err := driver.DefaultParameterConverter.ConvertValue(sample); err == nil && v != nil {
+		sample = v
+	}
+	return typeArgument{reflect.TypeOf(sample)}
+}
+
+type typeArgument struct {
+	typ reflect.Type
+}
+
+func (a typeArgument) Match(v driver.Value) bool {
+	return reflect.TypeOf(v) == a.typ
+}
+
+func (a typeArgument) String() string {
+	return fmt.Sprintf("AnyOfType(%s)", a.typ)
+}
+
+// InRange will return an Argument which matches any
+// numeric argument between min and max inclusive.
+func InRange(min, max float64) Argument {
+	return rangeArgument{min, max}
+}
+
+type rangeArgument struct {
+	min, max float64
+}
+
+func (a rangeArgument) Match(v driver.Value) bool {
+	f, ok := toFloat64(v)
+	return ok && f >= a.min && f <= a.max
+}
+
+func (a rangeArgument) String() string {
+	return fmt.Sprintf("InRange(%v, %v)", a.min, a.max)
+}
+
+// Approx will return an Argument which matches any
+// numeric argument within epsilon of expected, useful
+// for floating point values.
+func Approx(expected, epsilon float64) Argument {
+	return approxArgument{expected, epsilon}
+}
+
+type approxArgument struct {
+	expected, epsilon float64
+}
+
+func (a approxArgument) Match(v driver.Value) bool {
+	f, ok := toFloat64(v)
+	return ok && math.Abs(f-a.expected) <= a.epsilon
+}
+
+func (a approxArgument) String() string {
+	return fmt.Sprintf("Approx(%v, %v)", a.expected, a.epsilon)
+}
+
+// MatchesRegexp will return an Argument which matches any
+// string or []byte argument matching the regular expression
+// pattern. It panics if pattern does not compile.
+func MatchesRegexp(pattern string) Argument {
+	return regexpArgument{regexp.MustCompile(pattern)}
+}
+
+type regexpArgument struct {
+	re *regexp.Regexp
+}
+
+func (a regexpArgument) Match(v driver.Value) bool {
+	s, ok := toString(v)
+	return ok && a.re.MatchString(s)
+}
+
+func (a regexpArgument) String() string {
+	return fmt.Sprintf("MatchesRegexp(%q)", a.re.String())
+}
+
+// HasPrefix will return an Argument which matches any
+// string or []byte argument beginning with prefix.
+func HasPrefix(prefix string) Argument {
+	return prefixArgument(prefix)
+}
+
+type prefixArgument string
+
+func (a prefixArgument) Match(v driver.Value) bool {
+	s, ok := toString(v)
+	return ok && strings.HasPrefix(s, string(a))
+}
+
+func (a prefixArgument) String() string {
+	return fmt.Sprintf("HasPrefix(%q)", string(a))
+}
+
+// JSONEq will return an Argument which matches any
+// string or []byte argument holding a JSON document equal
+// to expected, ignoring formatting and the order of keys.
+func JSONEq(expected string) Argument {
+	return jsonArgument(expected)
+}
+
+type jsonArgument string
+
+func (a jsonArgument) Match(v driver.Value) bool {
+	s, ok := toString(v)
+	if !ok {
+		return false
+	}
+
+	var exp, act interface{}
+	if err := json.Unmarshal([]byte(a), &exp); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(s), &act); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(exp, act)
+}
+
+func (a jsonArgument) String() string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(a)); err != nil {
+		return fmt.Sprintf("JSONEq(%q)", string(a))
+	}
+	return fmt.Sprintf("JSONEq(%s)", buf.String())
+}
+
+// Not will return an Argument which matches any
+// argument the given Argument does not match.
+func Not(arg Argument) Argument {
+	return notArgument{arg}
+}
+
+type notArgument struct {
+	arg Argument
+}
+
+func (a notArgument) Match(v driver.Value) bool {
+	return !a.arg.Match(v)
+}
+
+func (a notArgument) String() string {
+	return fmt.Sprintf("Not(%s)", argumentString(a.arg))
+}
+
+// AllOf will return an Argument which matches any
+// argument all of the given Arguments match.
+func AllOf(args ...Argument) Argument {
+	return allOfArgument(args)
+}
+
+type allOfArgument []Argument
+
+func (a allOfArgument) Match(v driver.Value) bool {
+	for _, arg := range a {
+		if !arg.Match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a allOfArgument) String() string {
+	return "AllOf(" + argumentsString(a) + ")"
+}
+
+// AnyOf will return an Argument which matches any
+// argument at least one of the given Arguments match.
+func AnyOf(args ...Argument) Argument {
+	return anyOfArgument(args)
+}
+
+type anyOfArgument []Argument
+
+func (a anyOfArgument) Match(v driver.Value) bool {
+	for _, arg := range a {
+		if arg.Match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a anyOfArgument) String() string {
+	return "AnyOf(" + argumentsString(a) + ")"
+}
+
+// argumentString describes an Argument for failure messages.
+func argumentString(arg Argument) string {
+	if s, ok := arg.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", arg)
+}
+
+func argumentsString(args []Argument) string {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		strs[i] = argumentString(arg)
+	}
+	return strings.Join(strs, ", ")
+}
+
+func toFloat64(v driver.Value) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func toString(v driver.Value) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case []byte:
+		return string(s), true
+	}
+	return "", false
+}
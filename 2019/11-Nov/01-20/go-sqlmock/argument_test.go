@@ -2,6 +2,7 @@ package sqlmock
 
 import (
 	"database/sql/driver"
+	"strings"
 	"testing"
 	"time"
 )
@@ -56,3 +57,75 @@ func TestByteSleceArgument(t *testing.T) {
 		t.Errorf("there were unfunfilled expectations: %s", err)
 	}
 }
+
+func TestArgumentMatchers(t *testing.T) {
+	t.Parallel()
+
+	isEven := MatcherFunc(func(v driver.Value) bool {
+		i, ok := v.(int64)
+		return ok && i%2 == 0
+	})
+
+	cases := []struct {
+		arg   Argument
+		value driver.Value
+		match bool
+		str   string
+	}{
+		{AnyArg(), nil, true, "AnyArg()"},
+		{AnyOfType(time.Time{}), time.Now(), true, "AnyOfType(time.Time)"},
+		{AnyOfType(0), int64(5), true, "AnyOfType(int64)"},
+		{AnyOfType(0), "5", false, "AnyOfType(int64)"},
+		{InRange(1, 10), int64(10), true, "InRange(1, 10)"},
+		{InRange(1, 10), 0.5, false, "InRange(1, 10)"},
+		{InRange(1, 10), "5", false, "InRange(1, 10)"},
+		{Approx(3.14, 0.01), 3.141592, true, "Approx(3.14, 0.01)"},
+		{Approx(3.14, 0.01), 3.2, false, "Approx(3.14, 0.01)"},
+		{MatchesRegexp("^[a-z]+@example.com$"), "john@example.com", true, `MatchesRegexp("^[a-z]+@example.com$")`},
+		{MatchesRegexp("^[a-z]+@example.com$"), []byte("john@example.org"), false, `MatchesRegexp("^[a-z]+@example.com$")`},
+		{HasPrefix("SKU-"), "SKU-123", true, `HasPrefix("SKU-")`},
+		{HasPrefix("SKU-"), int64(123), false, `HasPrefix("SKU-")`},
+		{JSONEq(`{"a": 1, "b": [1, 2]}`), []byte(`{"b":[1,2],"a":1}`), true, `JSONEq({"a":1,"b":[1,2]})`},
+		{JSONEq(`{"a": 1}`), []byte(`{"a":2}`), false, `JSONEq({"a":1})`},
+		{JSONEq(`{"a": 1}`), []byte(`not json`), false, `JSONEq({"a":1})`},
+		{Not(AnyOfType("")), int64(1), true, "Not(AnyOfType(string))"},
+		{AllOf(AnyOfType(0), InRange(1, 10)), int64(5), true, "AllOf(AnyOfType(int64), InRange(1, 10))"},
+		{AllOf(AnyOfType(0), InRange(1, 10)), int64(11), false, "AllOf(AnyOfType(int64), InRange(1, 10))"},
+		{AnyOf(HasPrefix("a"), HasPrefix("b")), "bob", true, `AnyOf(HasPrefix("a"), HasPrefix("b"))`},
+		{AnyOf(HasPrefix("a"), HasPrefix("b")), "carl", false, `AnyOf(HasPrefix("a"), HasPrefix("b"))`},
+		{isEven, int64(4), true, "MatcherFunc"},
+		{AllOf(isEven, AnyTime{}), int64(4), false, "AllOf(MatcherFunc, sqlmock.AnyTime)"},
+	}
+
+	for i, c := range cases {
+		if match := c.arg.Match(c.value); match != c.match {
+			t.Errorf("expected match to be %t for %v at %d case, but got: %t", c.match, c.value, i, match)
+		}
+		if str := argumentString(c.arg); str != c.str {
+			t.Errorf(`expected matcher string "%s" at %d case, but got: "%s"`, c.str, i, str)
+		}
+	}
+}
+
+func TestArgumentMatcherFailureMessage(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE products").
+		WithArgs(AllOf(AnyOfType(0.0), InRange(0, 100)), HasPrefix("SKU-")).
+		WillReturnResult(NewResult(0, 1))
+
+	_, err = db.Exec("UPDATE products SET price = ? WHERE sku = ?", 150.0, "SKU-1")
+	if err == nil {
+		t.Fatal("expected an error, but got none")
+	}
+
+	exp := "argument 0: matcher AllOf(AnyOfType(float64), InRange(0, 100)) could not match actual [float64 - 150]"
+	if !strings.Contains(err.Error(), exp) {
+		t.Errorf(`expected error to contain "%s", but got: %s`, exp, err)
+	}
+}
@@ -0,0 +1,90 @@
+package sqlmock
+
+import "reflect"
+
+// Column is a mocked column metadata for rows.ColumnTypes()
+type Column struct {
+	name       string
+	dbType     string
+	nullable   bool
+	nullableOk bool
+	length     int64
+	lengthOk   bool
+	precision  int64
+	scale      int64
+	psOk       bool
+	scanType   reflect.Type
+}
+
+// NewColumn returns a Column with the given name and no
+// other metadata. Use the Column methods to declare the rest.
+func NewColumn(name string) *Column {
+	return &Column{name: name}
+}
+
+// Nullable declares whether the column may be null
+func (c *Column) Nullable(nullable bool) *Column {
+	c.nullable = nullable
+	c.nullableOk = true
+	return c
+}
+
+// OfType declares the database type name of the column, like "VARCHAR"
+// or "DECIMAL", and its scan type, taken from the type of sampleValue.
+func (c *Column) OfType(dbType string, sampleValue interface{}) *Column {
+	c.dbType = dbType
+	c.scanType = reflect.TypeOf(sampleValue)
+	return c
+}
+
+// WithLength declares the length of a variable length column type
+func (c *Column) WithLength(length int64) *Column {
+	c.length = length
+	c.lengthOk = true
+	return c
+}
+
+// WithPrecisionAndScale declares the precision and scale of a decimal column type
+func (c *Column) WithPrecisionAndScale(precision, scale int64) *Column {
+	c.precision = precision
+	c.scale = scale
+	c.psOk = true
+	return c
+}
+
+// Name returns the name of the column
+func (c *Column) Name() string {
+	return c.name
+}
+
+// DbType returns the database type name of the column
+func (c *Column) DbType() string {
+	return c.dbType
+}
+
+// IsNullable returns whether the column may be null, ok is false
+// when it was not declared
+func (c *Column) IsNullable() (nullable, ok bool) {
+	return c.nullable, c.nullableOk
+}
+
+// Length returns the column type length, ok is false
+// when it was not declared
+func (c *Column) Length() (length int64, ok bool) {
+	return c.length, c.lengthOk
+}
+
+// PrecisionScale returns the column type precision and scale, ok is
+// false when they were not declared
+func (c *Column) PrecisionScale() (precision, scale int64, ok bool) {
+	return c.precision, c.scale, c.psOk
+}
+
+// ScanType returns the Go type suitable for scanning into, which is
+// interface{} when it was not declared
+func (c *Column) ScanType() reflect.Type {
+	if c.scanType == nil {
+		return reflect.TypeOf(new(interface{})).Elem()
+	}
+	return c.scanType
+}
@@ -0,0 +1,59 @@
+package sqlmock
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestColumn(t *testing.T) {
+	now := time.Now()
+	column1 := NewColumn("test").OfType("VARCHAR", "").Nullable(true).WithLength(100)
+	column2 := NewColumn("number").OfType("DECIMAL", float64(0.0)).Nullable(false).WithPrecisionAndScale(10, 4)
+	column3 := NewColumn("when").OfType("TIMESTAMP", now)
+
+	if column1.ScanType().Kind() != reflect.String {
+		t.Errorf("string scanType mismatch: %v", column1.ScanType())
+	}
+	if column2.ScanType().Kind() != reflect.Float64 {
+		t.Errorf("float scanType mismatch: %v", column2.ScanType())
+	}
+	if column3.ScanType() != reflect.TypeOf(time.Time{}) {
+		t.Errorf("time scanType mismatch: %v", column3.ScanType())
+	}
+
+	nullable, ok := column1.IsNullable()
+	if !nullable || !ok {
+		t.Errorf("'test' column should be nullable")
+	}
+	nullable, ok = column2.IsNullable()
+	if nullable || !ok {
+		t.Errorf("'number' column should not be nullable")
+	}
+	if _, ok = column3.IsNullable(); ok {
+		t.Errorf("'when' column nullability should be unknown")
+	}
+
+	length, ok := column1.Length()
+	if length != 100 || !ok {
+		t.Errorf("'test' column length mismatch: %d, %t", length, ok)
+	}
+	if _, ok = column2.Length(); ok {
+		t.Errorf("'number' column length should be unknown")
+	}
+
+	precision, scale, ok := column2.PrecisionScale()
+	if precision != 10 || scale != 4 || !ok {
+		t.Errorf("'number' column precision and scale mismatch: %d, %d, %t", precision, scale, ok)
+	}
+	if _, _, ok = column1.PrecisionScale(); ok {
+		t.Errorf("'test' column precision and scale should be unknown")
+	}
+
+	if column1.DbType() != "VARCHAR" {
+		t.Errorf("'test' column type mismatch: %s", column1.DbType())
+	}
+	if typ := NewColumn("any").ScanType(); typ.Kind() != reflect.Interface {
+		t.Errorf("expected an undeclared scanType to be interface{}, but got: %v", typ)
+	}
+}
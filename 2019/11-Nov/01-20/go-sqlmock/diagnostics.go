@@ -0,0 +1,241 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// ExpectationsError is returned by ExpectationsWereMet when some
+// expectations were not met. Besides the unmet expectations it holds
+// the history of the calls the mock received, to help finding out
+// why they were not met.
+type ExpectationsError struct {
+	// Unmet are the expectations which were not met, in the order
+	// they were queued
+	Unmet []UnmetExpectation
+
+	// Calls are the database calls received by the mock, in the
+	// order they were made
+	Calls []Call
+}
+
+// UnmetExpectation is an expectation which was not met
+type UnmetExpectation struct {
+	// Index is the position of the expectation in the queue
+	Index int
+
+	// Expectation describes the expectation
+	Expectation string
+
+	// Reason tells why the expectation was not met
+	Reason string
+}
+
+// Call is a database call received by the mock
+type Call struct {
+	// Op is the name of the operation, like Begin, Query or Exec
+	Op string
+
+	// Query and Args are the query and its arguments, if any
+	Query string
+	Args  []driver.NamedValue
+
+	// Matched is the index of the expectation which matched the
+	// call, or -1 if no expectation matched
+	Matched int
+
+	// Err is the error returned for the call, if any
+	Err error
+
+	// NearMiss is the index of the expectation which came closest to
+	// matching a call no expectation matched, or -1 if there was no
+	// such expectation. NearMissReason tells why it did not match.
+	NearMiss       int
+	NearMissReason string
+}
+
+// String returns string representation
+func (c Call) String() string {
+	msg := c.Op
+	switch c.Op {
+	case "Prepare", "Query", "Exec":
+		msg += fmt.Sprintf(" '%s'", c.Query)
+	}
+	if len(c.Args) > 0 {
+		args := make([]string, len(c.Args))
+		for i, arg := range c.Args {
+			if arg.Name != "" {
+				args[i] = fmt.Sprintf("%s=%+v", arg.Name, arg.Value)
+			} else {
+				args[i] = fmt.Sprintf("%+v", arg.Value)
+			}
+		}
+		msg += " with args [" + strings.Join(args, ", ") + "]"
+	}
+
+	if c.Matched >= 0 {
+		msg += fmt.Sprintf(": matched expectation #%d", c.Matched)
+		if c.Err != nil {
+			msg += fmt.Sprintf(", which returned error: %s", c.Err)
+		}
+		return msg
+	}
+
+	msg += ": not matched"
+	if c.Err != nil {
+		msg += fmt.Sprintf(": %s", c.Err)
+	}
+	if c.NearMiss >= 0 {
+		msg += fmt.Sprintf("\n    closest expectation #%d: %s", c.NearMiss, c.NearMissReason)
+	}
+	return msg
+}
+
+// Error returns all the unmet expectations and the call history
+func (e *ExpectationsError) Error() string {
+	var msg string
+	if len(e.Unmet) == 1 {
+		msg = "there is 1 unmet expectation:"
+	} else {
+		msg = fmt.Sprintf("there are %d unmet expectations:", len(e.Unmet))
+	}
+	for _, u := range e.Unmet {
+		msg += fmt.Sprintf("\n  #%d %s: %s", u.Index, u.Reason, indent(u.Expectation, "    "))
+	}
+
+	if len(e.Calls) == 0 {
+		return msg + "\nno calls were received"
+	}
+	msg += "\ncalls received in order:"
+	for i, c := range e.Calls {
+		msg += fmt.Sprintf("\n  %d. %s", i+1, indent(c.String(), "  "))
+	}
+	return msg
+}
+
+func indent(s, prefix string) string {
+	return strings.Replace(s, "\n", "\n"+prefix, -1)
+}
+
+// unmetReason tells why an unfulfilled expectation was not met
+func unmetReason(e expectation) string {
+	var qb *queryBasedExpectation
+	switch ex := e.(type) {
+	case *ExpectedQuery:
+		qb = &ex.queryBasedExpectation
+	case *ExpectedExec:
+		qb = &ex.queryBasedExpectation
+	}
+	if qb == nil || qb.calls == 0 {
+		return "was not triggered"
+	}
+
+	min, _ := qb.times()
+	return fmt.Sprintf("was triggered %d of at least %d times", qb.calls, min)
+}
+
+// called records a call received by the mock, matched is the
+// expectation it triggered, if any.
+func (c *sqlmock) called(op, query string, args []namedValue, matched expectation, err error) {
+	call := Call{Op: op, Query: query, Matched: -1, Err: err, NearMiss: -1}
+	for _, arg := range args {
+		call.Args = append(call.Args, driver.NamedValue(arg))
+	}
+
+	if matched != nil {
+		call.Matched = c.expectationIndex(matched)
+	} else {
+		call.NearMiss, call.NearMissReason = c.nearMiss(op, query, args)
+	}
+
+	c.callsMu.Lock()
+	c.calls = append(c.calls, call)
+	c.callsMu.Unlock()
+}
+
+func (c *sqlmock) expectationIndex(e expectation) int {
+	for i, next := range c.expected {
+		if next == e {
+			return i
+		}
+	}
+	return -1
+}
+
+// nearMiss finds the unfulfilled expectation of the same operation
+// closest to matching a call: one matching but queued after another
+// expectation, then one matching the query but not the arguments, or
+// else the one with the most similar query.
+func (c *sqlmock) nearMiss(op, query string, args []namedValue) (int, string) {
+	best, bestScore, reason := -1, 0.0, ""
+	for i, next := range c.expected {
+		if expectationOp(next) != op {
+			continue
+		}
+		next.Lock()
+		exhausted := next.exhausted()
+		next.Unlock()
+
+		var qb *queryBasedExpectation
+		var expectSQL string
+		switch e := next.(type) {
+		case *ExpectedQuery:
+			qb, expectSQL = &e.queryBasedExpectation, e.expectSQL
+		case *ExpectedExec:
+			qb, expectSQL = &e.queryBasedExpectation, e.expectSQL
+		case *ExpectedPrepare:
+			expectSQL = e.expectSQL
+		}
+
+		score, why := 3.0, "matches, but another expectation was expected first"
+		if qb != nil || op == "Prepare" {
+			if err := c.queryMatcher.Match(expectSQL, query); err != nil {
+				score, why = tokenSimilarity(expectSQL, query), err.Error()
+			} else if qb != nil {
+				if err := qb.attemptArgMatch(args); err != nil {
+					score, why = 2, "arguments do not match: "+err.Error()
+				}
+			}
+		}
+
+		// an exhausted expectation is only worth reporting when it
+		// matches the call otherwise
+		if exhausted {
+			if qb == nil || score < 3 {
+				continue
+			}
+			_, max := qb.times()
+			why = fmt.Sprintf("matches, but was already triggered the maximum of %d times", max)
+		}
+
+		if best < 0 || score > bestScore {
+			best, bestScore, reason = i, score, why
+		}
+	}
+	return best, reason
+}
+
+// expectationOp returns the name of the operation an expectation
+// is for, as recorded in Call.Op
+func expectationOp(e expectation) string {
+	switch e.(type) {
+	case *ExpectedClose:
+		return "Close"
+	case *ExpectedBegin:
+		return "Begin"
+	case *ExpectedCommit:
+		return "Commit"
+	case *ExpectedRollback:
+		return "Rollback"
+	case *ExpectedPing:
+		return "Ping"
+	case *ExpectedPrepare:
+		return "Prepare"
+	case *ExpectedQuery:
+		return "Query"
+	case *ExpectedExec:
+		return "Exec"
+	}
+	return ""
+}
@@ -0,0 +1,172 @@
+package sqlmock
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpectationsErrorReportsAllUnmet(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products").WithArgs(5).WillReturnResult(NewResult(0, 1))
+	mock.ExpectQuery("SELECT (.+) FROM products").WillReturnRows(NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	if _, err := db.Begin(); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	err = mock.ExpectationsWereMet()
+	exErr, ok := err.(*ExpectationsError)
+	if !ok {
+		t.Fatalf("expected an *ExpectationsError, but got: %T - %v", err, err)
+	}
+
+	if len(exErr.Unmet) != 3 {
+		t.Fatalf("expected 3 unmet expectations, but got: %d", len(exErr.Unmet))
+	}
+	for i, u := range exErr.Unmet {
+		if u.Index != i+1 || u.Reason != "was not triggered" {
+			t.Errorf("unexpected unmet expectation at %d: #%d %s", i, u.Index, u.Reason)
+		}
+	}
+
+	if len(exErr.Calls) != 1 || exErr.Calls[0].Op != "Begin" || exErr.Calls[0].Matched != 0 {
+		t.Errorf("expected a single Begin call matching the first expectation, but got: %+v", exErr.Calls)
+	}
+	if !strings.HasPrefix(err.Error(), "there are 3 unmet expectations:") {
+		t.Errorf("unexpected error message: %s", err)
+	}
+}
+
+func TestExpectationsErrorCallHistory(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE products").WithArgs(5).WillReturnResult(NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM products").WillReturnResult(NewResult(0, 1))
+
+	if _, err := db.Exec("UPDATE products SET price = 1 WHERE id = ?", 6); err == nil {
+		t.Error("expected an error for mismatched arguments")
+	}
+	if _, err := db.Exec("UPDATE products SET price = 1 WHERE id = ?", 5); err != nil {
+		t.Errorf("error was not expected, but got: %s", err)
+	}
+	if _, err := db.Exec("DELETE FROM orders"); err == nil {
+		t.Error("expected an error for a mismatched query")
+	}
+
+	err = mock.ExpectationsWereMet()
+	exErr, ok := err.(*ExpectationsError)
+	if !ok {
+		t.Fatalf("expected an *ExpectationsError, but got: %T - %v", err, err)
+	}
+
+	if len(exErr.Unmet) != 1 || exErr.Unmet[0].Index != 1 {
+		t.Errorf("expected the DELETE expectation to be unmet, but got: %+v", exErr.Unmet)
+	}
+
+	calls := exErr.Calls
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, but got: %d", len(calls))
+	}
+
+	if calls[0].Matched != -1 || calls[0].NearMiss != 0 {
+		t.Errorf("expected the first call to miss the first expectation, but got: %+v", calls[0])
+	}
+	if exp := "arguments do not match: argument 0: expected [int64 - 5] does not match actual [int64 - 6]"; calls[0].NearMissReason != exp {
+		t.Errorf(`expected near miss reason "%s", but got: "%s"`, exp, calls[0].NearMissReason)
+	}
+	if len(calls[0].Args) != 1 || calls[0].Args[0].Value != int64(6) {
+		t.Errorf("expected the call arguments to be recorded, but got: %+v", calls[0].Args)
+	}
+
+	if calls[1].Matched != 0 || calls[1].Err != nil {
+		t.Errorf("expected the second call to match the first expectation, but got: %+v", calls[1])
+	}
+
+	if calls[2].Matched != -1 || calls[2].Err == nil {
+		t.Errorf("expected the third call not to match, but got: %+v", calls[2])
+	}
+	if calls[2].NearMiss != 1 || !strings.HasPrefix(calls[2].NearMissReason, "could not match actual sql") {
+		t.Errorf("expected the third call to nearly match the second expectation, but got: %+v", calls[2])
+	}
+
+	msg := err.Error()
+	for _, exp := range []string{
+		"there is 1 unmet expectation:\n  #1 was not triggered: ExepectedExec",
+		"calls received in order:",
+		"1. Exec 'UPDATE products SET price = 1 WHERE id = ?' with args [6]: not matched: ",
+		"closest expectation #0: arguments do not match",
+		"2. Exec 'UPDATE products SET price = 1 WHERE id = ?' with args [5]: matched expectation #0",
+		"3. Exec 'DELETE FROM orders': not matched",
+	} {
+		if !strings.Contains(msg, exp) {
+			t.Errorf("expected error message to contain %q, but got:\n%s", exp, msg)
+		}
+	}
+}
+
+func TestExpectationsErrorUnorderedNearMiss(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	if err := mock.(*sqlmock).Commit(); err == nil {
+		t.Error("expected an error for a Commit before Begin")
+	}
+
+	exErr, ok := mock.ExpectationsWereMet().(*ExpectationsError)
+	if !ok {
+		t.Fatal("expected an *ExpectationsError")
+	}
+	call := exErr.Calls[0]
+	if call.Op != "Commit" || call.NearMiss != 1 || call.NearMissReason != "matches, but another expectation was expected first" {
+		t.Errorf("unexpected call: %+v", call)
+	}
+}
+
+func TestExpectationsErrorRowsNotClosed(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id"}).AddRow(1)).RowsWillBeClosed()
+
+	rows, err := db.Query("SELECT id FROM products")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	exErr, ok := mock.ExpectationsWereMet().(*ExpectationsError)
+	if !ok {
+		t.Fatal("expected an *ExpectationsError")
+	}
+	if len(exErr.Unmet) != 1 || exErr.Unmet[0].Reason != "query rows were not closed" {
+		t.Errorf("expected the rows not to be closed, but got: %+v", exErr.Unmet)
+	}
+	rows.Close()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 // an expectation interface
 type expectation interface {
 	fulfilled() bool
+	exhausted() bool
 	Lock()
 	Unlock()
 	String() string
@@ -30,6 +31,14 @@ func (e *commonExpectation) fulfilled() bool {
 	return e.triggered
 }
 
+func (e *commonExpectation) exhausted() bool {
+	return e.triggered
+}
+
+func (e *commonExpectation) trigger() {
+	e.triggered = true
+}
+
 // ExpectedClose is used to manage *sql.DB.Close expectation
 // returned by *Sqlmock.ExpectedClose.
 type ExpectedClose struct {
@@ -122,24 +131,93 @@ func (e *ExpectedRollback) String() string {
 	return msg
 }
 
+// ExpectedPing is used to manage *sql.DB.Ping expectations.
+// Returned by *Sqlmock.ExpectPing.
+type ExpectedPing struct {
+	commonExpectation
+	delay time.Duration
+}
+
+// WillReturnError allows to set an error for expected database ping
+func (e *ExpectedPing) WillReturnError(err error) *ExpectedPing {
+	e.err = err
+	return e
+}
+
+// WillDelayFor allows to specify duration for which it will delay result.
+// May be used together with Context.
+func (e *ExpectedPing) WillDelayFor(duration time.Duration) *ExpectedPing {
+	e.delay = duration
+	return e
+}
+
+// String returns string representation.
+func (e *ExpectedPing) String() string {
+	msg := "ExpectedPing => expecting database Ping"
+	if e.err != nil {
+		msg += fmt.Sprintf(", which should return error: %s", e.err)
+	}
+	return msg
+}
+
 // ExpectedQuery is used to manage *sql.DB.Query, *sql.DB.QueryRow, *sql.Tx.Query,
 // *sql.Tx.QueryRow, *sql.Stmt.Query or *sql.Stmt.QueryRow expectations.
 type ExpectedQuery struct {
 	queryBasedExpectation
 	rows             driver.Rows
+	rowsFn           func(args []driver.Value) (*Rows, error)
 	delay            time.Duration
 	rowsMustBeClosed bool
-	rowsWereClosed   bool
+
+	// the number of rows returned by the triggered query and how
+	// many of them were closed, guarded by the expectation lock
+	rowsReturned int
+	rowsClosed   int
 }
 
 // WithArgs will match given expected args to actual database query arguments.
 // if at least one argument does not match, it will return an error. For specific
 // arguemtns an sqlmock.Argument interface can be used to match an argument.
+// Arguments given as sql.Named are matched by name instead of position.
 func (e *ExpectedQuery) WithArgs(args ...driver.Value) *ExpectedQuery {
 	e.args = args
 	return e
 }
 
+// Times expects the query to be triggered exactly n times.
+func (e *ExpectedQuery) Times(n int) *ExpectedQuery {
+	e.setTimes(n, n)
+	return e
+}
+
+// AnyTimes allows the query to be triggered any number of times,
+// including never.
+func (e *ExpectedQuery) AnyTimes() *ExpectedQuery {
+	e.setTimes(0, -1)
+	return e
+}
+
+// Maybe makes the query optional, so it does not have to be triggered.
+func (e *ExpectedQuery) Maybe() *ExpectedQuery {
+	_, max := e.times()
+	e.setTimes(0, max)
+	return e
+}
+
+// MinTimes expects the query to be triggered at least n times. Unless
+// MaxTimes is set as well there is no maximum.
+func (e *ExpectedQuery) MinTimes(n int) *ExpectedQuery {
+	e.setMinTimes(n)
+	return e
+}
+
+// MaxTimes allows the query to be triggered at most n times. Unless
+// MinTimes is set as well there is no minimum.
+func (e *ExpectedQuery) MaxTimes(n int) *ExpectedQuery {
+	e.setMaxTimes(n)
+	return e
+}
+
 // RowsWillBeClosed expects this query rows to be closed.
 func (e *ExpectedQuery) RowsWillBeClosed() *ExpectedQuery {
 	e.rowsMustBeClosed = true
@@ -173,8 +251,12 @@ func (e *ExpectedQuery) String() string {
 		msg = strings.TrimSpace(msg)
 	}
 
+	msg += e.timesString()
+
 	if e.rows != nil {
 		msg += fmt.Sprintf("\n  = %s", e.rows)
+	} else if e.rowsFn != nil {
+		msg += "\n  - should return rows computed from the arguments"
 	}
 
 	if e.err != nil {
@@ -184,6 +266,8 @@ func (e *ExpectedQuery) String() string {
 }
 
 // WillReturnRows specifies the set of resulting rows that will be returned
+// by the triggered query. More than one Rows are returned as multiple result
+// sets, which are advanced with *sql.Rows.NextResultSet.
 func (e *ExpectedQuery) WillReturnRows(rows ...*Rows) *ExpectedQuery {
 	sets := make([]*Rows, len(rows))
 	for i, r := range rows {
@@ -193,22 +277,100 @@ func (e *ExpectedQuery) WillReturnRows(rows ...*Rows) *ExpectedQuery {
 	return e
 }
 
+// WillReturnRowsFunc arranges for the triggered query to return the rows
+// computed by fn from the query arguments, or the error it returns, so a
+// single expectation can answer queries for different arguments:
+//
+//	mock.ExpectQuery("SELECT (.+) FROM person WHERE id = ?").
+//		WithArgs(sqlmock.AnyArg()).
+//		WillReturnRowsFunc(func(args []driver.Value) (*sqlmock.Rows, error) {
+//			person, ok := people[args[0].(int64)]
+//			if !ok {
+//				return sqlmock.NewRows(columns), nil
+//			}
+//			return sqlmock.NewRows(columns).AddRow(person.ID, person.FullName), nil
+//		}).
+//		AnyTimes()
+//
+// The function is called each time the query is triggered.
+func (e *ExpectedQuery) WillReturnRowsFunc(fn func(args []driver.Value) (*Rows, error)) *ExpectedQuery {
+	e.rowsFn = fn
+	return e
+}
+
+// nextRows returns the rows of the triggered query
+func (e *ExpectedQuery) nextRows(args []namedValue) (driver.Rows, error) {
+	if e.rowsFn == nil {
+		if rs, ok := e.rows.(*rowSets); ok {
+			e.rowsReturned++
+			return rs.clone(), nil
+		}
+		return e.rows, nil
+	}
+
+	rows, err := e.rowsFn(argValues(args))
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		return nil, fmt.Errorf("rows function of %T as %+v returned no rows", e, e)
+	}
+	e.rowsReturned++
+	return &rowSets{sets: []*Rows{rows}, ex: e}, nil
+}
+
 // ExpectedExec is used to manage *sql.DB.Exec, *sql.Tx.Exec or *sql.Stmt.Exec expectations.
 // Returned by *Sqlmock.ExpectExec.
 type ExpectedExec struct {
 	queryBasedExpectation
-	result driver.Result
-	delay  time.Duration
+	result   driver.Result
+	resultFn func(args []driver.Value) (driver.Result, error)
+	delay    time.Duration
 }
 
 // WithArgs will match given expcted args to actual database exec operation arguments.
 // if at least one argument does not match, it will return an error. For specific
 // arguments an sqlmock.Argument interface can be used to match an argument.
+// Arguments given as sql.Named are matched by name instead of position.
 func (e *ExpectedExec) WithArgs(args ...driver.Value) *ExpectedExec {
 	e.args = args
 	return e
 }
 
+// Times expects the exec to be triggered exactly n times.
+func (e *ExpectedExec) Times(n int) *ExpectedExec {
+	e.setTimes(n, n)
+	return e
+}
+
+// AnyTimes allows the exec to be triggered any number of times,
+// including never.
+func (e *ExpectedExec) AnyTimes() *ExpectedExec {
+	e.setTimes(0, -1)
+	return e
+}
+
+// Maybe makes the exec optional, so it does not have to be triggered.
+func (e *ExpectedExec) Maybe() *ExpectedExec {
+	_, max := e.times()
+	e.setTimes(0, max)
+	return e
+}
+
+// MinTimes expects the exec to be triggered at least n times. Unless
+// MaxTimes is set as well there is no maximum.
+func (e *ExpectedExec) MinTimes(n int) *ExpectedExec {
+	e.setMinTimes(n)
+	return e
+}
+
+// MaxTimes allows the exec to be triggered at most n times. Unless
+// MinTimes is set as well there is no minimum.
+func (e *ExpectedExec) MaxTimes(n int) *ExpectedExec {
+	e.setMaxTimes(n)
+	return e
+}
+
 // WillReturnError allows to set an error for expected database exec action
 func (e *ExpectedExec) WillReturnError(err error) *ExpectedExec {
 	e.err = err
@@ -238,6 +400,8 @@ func (e *ExpectedExec) String() string {
 		msg += strings.Join(margs, "\n")
 	}
 
+	msg += e.timesString()
+
 	if e.result != nil {
 		res, _ := e.result.(*result)
 		msg += "\n - should return Result having:"
@@ -246,6 +410,8 @@ func (e *ExpectedExec) String() string {
 		if res.err != nil {
 			msg += fmt.Sprintf("\n    Error: %s", res.err)
 		}
+	} else if e.resultFn != nil {
+		msg += "\n - should return Result computed from the arguments"
 	}
 
 	if e.err != nil {
@@ -262,6 +428,30 @@ func (e *ExpectedExec) WillReturnResult(result driver.Result) *ExpectedExec {
 	return e
 }
 
+// WillReturnResultFunc arranges for the triggered Exec() to return the
+// result computed by fn from the exec arguments, or the error it returns.
+// The function is called each time the exec is triggered.
+func (e *ExpectedExec) WillReturnResultFunc(fn func(args []driver.Value) (driver.Result, error)) *ExpectedExec {
+	e.resultFn = fn
+	return e
+}
+
+// nextResult returns the result of the triggered exec
+func (e *ExpectedExec) nextResult(args []namedValue) (driver.Result, error) {
+	if e.resultFn == nil {
+		return e.result, nil
+	}
+
+	res, err := e.resultFn(argValues(args))
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, fmt.Errorf("result function of %T as %+v returned no result", e, e)
+	}
+	return res, nil
+}
+
 type ExpectedPrepare struct {
 	commonExpectation
 	mock         *sqlmock
@@ -303,6 +493,7 @@ func (e *ExpectedPrepare) ExpectQuery() *ExpectedQuery {
 	eq := &ExpectedQuery{}
 	eq.expectSQL = e.expectSQL
 	eq.converter = e.mock.converter
+	e.mock.expected = append(e.mock.expected, eq)
 	return eq
 }
 
@@ -338,16 +529,102 @@ type queryBasedExpectation struct {
 	expectSQL string
 	converter driver.ValueConverter
 	args      []driver.Value
+
+	// the number of times the expectation must and may be
+	// triggered, a bound which is not set defaults to once,
+	// or to no bound when only the other one is set. A
+	// timesMax < 0 means no limit.
+	minSet   bool
+	maxSet   bool
+	timesMin int
+	timesMax int
+	calls    int
+}
+
+func (e *queryBasedExpectation) times() (min, max int) {
+	min, max = 1, 1
+	switch {
+	case e.minSet:
+		min = e.timesMin
+	case e.maxSet:
+		min = 0
+	}
+	switch {
+	case e.maxSet:
+		max = e.timesMax
+	case e.minSet:
+		max = -1
+	}
+	return min, max
+}
+
+func (e *queryBasedExpectation) setTimes(min, max int) {
+	e.setMinTimes(min)
+	e.setMaxTimes(max)
+}
+
+func (e *queryBasedExpectation) fulfilled() bool {
+	min, _ := e.times()
+	return e.calls >= min
+}
+
+func (e *queryBasedExpectation) exhausted() bool {
+	_, max := e.times()
+	return max >= 0 && e.calls >= max
+}
+
+func (e *queryBasedExpectation) trigger() {
+	e.triggered = true
+	e.calls++
+}
+
+func (e *queryBasedExpectation) matches(m QueryMatcher, query string, args []namedValue) bool {
+	return m.Match(e.expectSQL, query) == nil && e.attemptArgMatch(args) == nil
+}
+
+// timesString describes how many times the expectation must be
+// triggered, if not exactly once.
+func (e *queryBasedExpectation) timesString() string {
+	min, max := e.times()
+	switch {
+	case min == 1 && max == 1:
+		return ""
+	case min == max:
+		return fmt.Sprintf("\n  - is expected %d times", min)
+	case max < 0:
+		return fmt.Sprintf("\n  - is expected at least %d times", min)
+	}
+	return fmt.Sprintf("\n  - is expected %d to %d times", min, max)
+}
+
+func (e *queryBasedExpectation) setMinTimes(n int) {
+	e.minSet = true
+	e.timesMin = n
+}
+
+func (e *queryBasedExpectation) setMaxTimes(n int) {
+	e.maxSet = true
+	e.timesMax = n
+}
+
+// argValues returns the values of the query arguments, in order
+func argValues(args []namedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return values
 }
 
 func (e *queryBasedExpectation) attemptArgMatch(args []namedValue) (err error) {
 	// catch panic
 	defer func() {
 		if e := recover(); e != nil {
-			_, ok := e.(error)
-			if !ok {
-				err = fmt.Errorf(e.(string))
+			if perr, ok := e.(error); ok {
+				err = perr
+				return
 			}
+			err = fmt.Errorf("%v", e)
 		}
 	}()
 
@@ -363,32 +640,54 @@ func (e *queryBasedExpectation) argsMatches(args []namedValue) error {
 		return fmt.Errorf("expected %d, but got %d arguments", len(e.args), len(args))
 	}
 
-	for k, v := range args {
-		matcher, ok := e.args[k].(Argument)
-		if ok {
+	for k, dval := range e.args {
+		// named arguments are matched by name, the others by ordinal position
+		var v namedValue
+		var label string
+		if named, isNamed := dval.(sql.NamedArg); isNamed {
+			label = fmt.Sprintf("named argument %q", named.Name)
+			found := false
+			for _, arg := range args {
+				if arg.Name == named.Name {
+					v, found = arg, true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("%s: no actual argument with this name was given", label)
+			}
+			dval = named.Value
+		} else {
+			label = fmt.Sprintf("argument %d", k)
+			v = args[k]
+			if k+1 != v.Ordinal {
+				return fmt.Errorf("%s: ordinal position: %d does not match expected: %d", label, v.Ordinal, k+1)
+			}
+		}
+
+		if matcher, ok := dval.(Argument); ok {
 			if !matcher.Match(v.Value) {
-				return fmt.Errorf("matcher %T could not match %d argument %T - %+v", matcher, k, args[k], args[k])
+				return fmt.Errorf("%s: matcher %s could not match actual [%T - %+v]", label, argumentString(matcher), v.Value, v.Value)
 			}
 			continue
 		}
-		dval := e.args[k]
-		if named, isNamed := dval.(sql.NamedArg); isNamed {
-			dval = named.Value
-			if v.Name != named.Name {
-				return fmt.Errorf("named argument %d: name: \"%s\" does not match expected: \"%s\"", k, v.Name, named.Name)
+
+		// output parameters are passed through as they are
+		if out, ok := dval.(sql.Out); ok {
+			if !reflect.DeepEqual(out, v.Value) {
+				return fmt.Errorf("%s: expected [%T - %+v] does not match actual [%T - %+v]", label, out, out, v.Value, v.Value)
 			}
-		} else if k+1 != v.Ordinal {
-			return fmt.Errorf("argument %d: ordinal position: %d does not match expected: %d", k, k+1, v.Ordinal)
+			continue
 		}
 
 		// convert to driver converter
 		darg, err := e.converter.ConvertValue(dval)
 		if err != nil {
-			return fmt.Errorf("could not convert %d argument %T - %+v to driver value: %s", k, e.args[k], e.args[k], err)
+			return fmt.Errorf("%s: could not convert expected [%T - %+v] to driver value: %s", label, dval, dval, err)
 		}
 
 		if !reflect.DeepEqual(darg, v.Value) {
-			return fmt.Errorf("argument %d expected [%T - %+v] does not match actual [%T - %+v", k, darg, darg, v.Value, v.Value)
+			return fmt.Errorf("%s: expected [%T - %+v] does not match actual [%T - %+v]", label, darg, darg, v.Value, v.Value)
 		}
 	}
 	return nil
@@ -0,0 +1,415 @@
+package sqlmock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExpectationTimes(t *testing.T) {
+	t.Parallel()
+	for _, ordered := range []bool{true, false} {
+		db, mock, err := New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		mock.MatchExpectationsInOrder(ordered)
+		mock.ExpectExec("UPDATE counters").WillReturnResult(NewResult(0, 1)).Times(3)
+		mock.ExpectQuery("SELECT count").WillReturnRows(NewRows([]string{"count"}).AddRow(3)).Times(2)
+
+		for i := 0; i < 3; i++ {
+			if _, err := db.Exec("UPDATE counters SET n = n + 1"); err != nil {
+				t.Errorf("error was not expected on exec %d (ordered: %v), but got: %s", i, ordered, err)
+			}
+		}
+		if _, err := db.Exec("UPDATE counters SET n = n + 1"); err == nil {
+			t.Errorf("expected an error on the fourth exec (ordered: %v)", ordered)
+		}
+
+		// the rows are returned in full each time
+		for i := 0; i < 2; i++ {
+			var count int
+			if err := db.QueryRow("SELECT count(*) FROM counters").Scan(&count); err != nil {
+				t.Errorf("error was not expected on query %d (ordered: %v), but got: %s", i, ordered, err)
+			}
+			if count != 3 {
+				t.Errorf("expected count 3 on query %d, but got: %d", i, count)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+		db.Close()
+	}
+}
+
+func TestExpectationAnyTimesAndMaybe(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM cache").WillReturnRows(NewRows([]string{"id"})).Maybe()
+	mock.ExpectExec("INSERT INTO audit").WillReturnResult(NewResult(1, 1)).AnyTimes()
+	mock.ExpectExec("UPDATE products").WithArgs(5).WillReturnResult(NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	// the optional query and audit inserts are skipped in order
+	for i := 0; i < 2; i++ {
+		if _, err := tx.Exec("INSERT INTO audit(action) VALUES (?)", "update"); err != nil {
+			t.Errorf("error was not expected, but got: %s", err)
+		}
+	}
+	if _, err := tx.Exec("UPDATE products SET views = views + 1 WHERE id = ?", 5); err != nil {
+		t.Errorf("error was not expected, but got: %s", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("error was not expected, but got: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestExpectationMinTimesUnmet(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM sessions").WillReturnResult(NewResult(0, 1)).MinTimes(2)
+
+	if _, err := db.Exec("DELETE FROM sessions"); err != nil {
+		t.Errorf("error was not expected, but got: %s", err)
+	}
+
+	err = mock.ExpectationsWereMet()
+	exErr, ok := err.(*ExpectationsError)
+	if !ok {
+		t.Fatalf("expected an *ExpectationsError, but got: %T - %v", err, err)
+	}
+	if len(exErr.Unmet) != 1 || exErr.Unmet[0].Reason != "was triggered 1 of at least 2 times" {
+		t.Errorf("unexpected unmet expectations: %+v", exErr.Unmet)
+	}
+	if !strings.Contains(exErr.Unmet[0].Expectation, "is expected at least 2 times") {
+		t.Errorf("expected the expectation to describe its times, but got: %s", exErr.Unmet[0].Expectation)
+	}
+
+	if _, err := db.Exec("DELETE FROM sessions"); err != nil {
+		t.Errorf("error was not expected, but got: %s", err)
+	}
+	if _, err := db.Exec("DELETE FROM sessions"); err != nil {
+		t.Errorf("error was not expected, there is no maximum, but got: %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestExpectationMaxTimesExceeded(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT name FROM users").
+		WithArgs(1).
+		WillReturnRows(NewRows([]string{"name"}).AddRow("john")).
+		MaxTimes(2)
+
+	for i := 0; i < 2; i++ {
+		var name string
+		if err := db.QueryRow("SELECT name FROM users WHERE id = ?", 1).Scan(&name); err != nil {
+			t.Errorf("error was not expected, but got: %s", err)
+		}
+	}
+
+	var name string
+	err = db.QueryRow("SELECT name FROM users WHERE id = ?", 1).Scan(&name)
+	if err == nil || err == sql.ErrNoRows {
+		t.Fatalf("expected an error on the third query, but got: %v", err)
+	}
+
+	err = mock.ExpectationsWereMet()
+	if err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	// the exceeded expectation is reported as the near miss
+	calls := mock.(*sqlmock).calls
+	last := calls[len(calls)-1]
+	if last.Matched != -1 || last.NearMiss != 0 {
+		t.Fatalf("expected the last call to miss expectation #0, but got: %+v", last)
+	}
+	if last.NearMissReason != "matches, but was already triggered the maximum of 2 times" {
+		t.Errorf("unexpected near miss reason: %s", last.NearMissReason)
+	}
+}
+
+func TestWillReturnRowsFunc(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	names := map[int64]string{1: "John", 2: "Paul"}
+	mock.ExpectQuery("SELECT fullname FROM person WHERE id = ?").
+		WithArgs(AnyArg()).
+		WillReturnRowsFunc(func(args []driver.Value) (*Rows, error) {
+			rows := NewRows([]string{"fullname"})
+			if name, ok := names[args[0].(int64)]; ok {
+				rows.AddRow(name)
+			}
+			return rows, nil
+		}).
+		AnyTimes()
+
+	for id, expected := range names {
+		var name string
+		if err := db.QueryRow("SELECT fullname FROM person WHERE id = ?", id).Scan(&name); err != nil {
+			t.Errorf("error was not expected, but got: %s", err)
+		}
+		if name != expected {
+			t.Errorf("expected name %q for id %d, but got: %q", expected, id, name)
+		}
+	}
+
+	var name string
+	if err := db.QueryRow("SELECT fullname FROM person WHERE id = ?", 3).Scan(&name); err != sql.ErrNoRows {
+		t.Errorf("expected sql.ErrNoRows for an unknown id, but got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWillReturnRowsFuncError(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT fullname FROM person").
+		WillReturnRowsFunc(func(args []driver.Value) (*Rows, error) {
+			return nil, fmt.Errorf("person table is locked")
+		})
+
+	_, err = db.Query("SELECT fullname FROM person")
+	if err == nil || err.Error() != "person table is locked" {
+		t.Errorf("expected the error of the rows function, but got: %v", err)
+	}
+
+	calls := mock.(*sqlmock).calls
+	if len(calls) != 1 || calls[0].Matched != 0 || calls[0].Err == nil {
+		t.Errorf("expected the call to match and record the error, but got: %+v", calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWillReturnResultFunc(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM person WHERE id IN").
+		WillReturnResultFunc(func(args []driver.Value) (driver.Result, error) {
+			return NewResult(0, int64(len(args))), nil
+		}).
+		Times(2)
+
+	for n, args := range [][]interface{}{{1, 2, 3}, {4}} {
+		res, err := db.Exec("DELETE FROM person WHERE id IN (?)", args...)
+		if err != nil {
+			t.Fatalf("error was not expected, but got: %s", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			t.Errorf("error was not expected, but got: %s", err)
+		}
+		if affected != int64(len(args)) {
+			t.Errorf("expected %d affected rows on exec %d, but got: %d", len(args), n, affected)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestExpectationTimesBounds(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		set      func(e *ExpectedQuery)
+		min, max int
+	}{
+		{"default", func(e *ExpectedQuery) {}, 1, 1},
+		{"MinTimes(1)", func(e *ExpectedQuery) { e.MinTimes(1) }, 1, -1},
+		{"MaxTimes(1)", func(e *ExpectedQuery) { e.MaxTimes(1) }, 0, 1},
+		{"MinTimes(1).MaxTimes(3)", func(e *ExpectedQuery) { e.MinTimes(1).MaxTimes(3) }, 1, 3},
+		{"MaxTimes(3).MinTimes(1)", func(e *ExpectedQuery) { e.MaxTimes(3).MinTimes(1) }, 1, 3},
+		{"MaxTimes(1).MinTimes(1)", func(e *ExpectedQuery) { e.MaxTimes(1).MinTimes(1) }, 1, 1},
+		{"MinTimes(1).MaxTimes(1)", func(e *ExpectedQuery) { e.MinTimes(1).MaxTimes(1) }, 1, 1},
+		{"MinTimes(2).MaxTimes(1)", func(e *ExpectedQuery) { e.MinTimes(2).MaxTimes(1) }, 2, 1},
+		{"Times(2).Maybe()", func(e *ExpectedQuery) { e.Times(2).Maybe() }, 0, 2},
+		{"Maybe()", func(e *ExpectedQuery) { e.Maybe() }, 0, 1},
+		{"AnyTimes()", func(e *ExpectedQuery) { e.AnyTimes() }, 0, -1},
+	}
+	for _, c := range cases {
+		e := &ExpectedQuery{}
+		c.set(e)
+		if min, max := e.times(); min != c.min || max != c.max {
+			t.Errorf("%s: expected to be triggered %d to %d times, but got %d to %d", c.name, c.min, c.max, min, max)
+		}
+	}
+}
+
+func TestExpectationMinTimesOneIsRequired(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE counters").WillReturnResult(NewResult(0, 1)).MinTimes(1).MaxTimes(3)
+
+	err = mock.ExpectationsWereMet()
+	if exErr, ok := err.(*ExpectationsError); !ok || len(exErr.Unmet) != 1 || exErr.Unmet[0].Reason != "was not triggered" {
+		t.Errorf("expected the exec to be required, but got: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := db.Exec("UPDATE counters SET n = n + 1"); err != nil {
+			t.Errorf("error was not expected on exec %d, but got: %s", i, err)
+		}
+	}
+	if _, err := db.Exec("UPDATE counters SET n = n + 1"); err == nil {
+		t.Error("expected an error on the fourth exec")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOptionalQueryRowsWillBeClosed(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM cache").WillReturnRows(NewRows([]string{"id"})).RowsWillBeClosed().Maybe()
+	mock.ExpectQuery("SELECT (.+) FROM audit").WillReturnRows(NewRows([]string{"id"})).RowsWillBeClosed().AnyTimes()
+
+	// neither query is triggered, so their rows need not be closed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	rows, err := db.Query("SELECT id FROM audit")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	err = mock.ExpectationsWereMet()
+	if exErr, ok := err.(*ExpectationsError); !ok || len(exErr.Unmet) != 1 || exErr.Unmet[0].Reason != "query rows were not closed" {
+		t.Errorf("expected the audit rows not to be closed, but got: %v", err)
+	}
+	rows.Close()
+}
+
+func TestRepeatedQueryRowsWillBeClosed(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM audit").
+		WillReturnRows(NewRows([]string{"id"}).AddRow(1)).
+		RowsWillBeClosed().
+		Times(2)
+
+	first, err := db.Query("SELECT id FROM audit")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	second, err := db.Query("SELECT id FROM audit")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	// closing the rows of one call does not count for the other
+	first.Close()
+	err = mock.ExpectationsWereMet()
+	if exErr, ok := err.(*ExpectationsError); !ok || len(exErr.Unmet) != 1 || exErr.Unmet[0].Reason != "query rows were not closed" {
+		t.Errorf("expected the second rows not to be closed, but got: %v", err)
+	}
+
+	second.Close()
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestConcurrentQueryRowsWillBeClosed(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery("SELECT (.+) FROM audit").
+		WillReturnRows(NewRows([]string{"id"}).AddRow(1)).
+		RowsWillBeClosed().
+		AnyTimes()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var id int
+			if err := db.QueryRow("SELECT id FROM audit").Scan(&id); err != nil {
+				t.Errorf("error was not expected, but got: %s", err)
+			}
+			_ = mock.ExpectationsWereMet()
+		}()
+	}
+	wg.Wait()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
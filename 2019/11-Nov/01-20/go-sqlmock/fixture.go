@@ -0,0 +1,241 @@
+package sqlmock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"time"
+)
+
+// Fixture operations, in the order they were recorded
+const (
+	FixtureBegin    = "begin"
+	FixtureCommit   = "commit"
+	FixtureRollback = "rollback"
+	FixturePrepare  = "prepare"
+	FixtureQuery    = "query"
+	FixtureExec     = "exec"
+)
+
+// Fixture is a sequence of database calls recorded by a Recorder,
+// which can be replayed as expectations with Sqlmock.ExpectFixture.
+type Fixture struct {
+	Calls []*FixtureCall `json:"calls"`
+}
+
+// FixtureCall is a single recorded database call
+type FixtureCall struct {
+	Op           string           `json:"op"`
+	Query        string           `json:"query,omitempty"`
+	Args         []FixtureArg     `json:"args,omitempty"`
+	Columns      []string         `json:"columns,omitempty"`
+	Rows         [][]FixtureValue `json:"rows,omitempty"`
+	LastInsertID int64            `json:"lastInsertId,omitempty"`
+	RowsAffected int64            `json:"rowsAffected,omitempty"`
+	Error        string           `json:"error,omitempty"`
+
+	// implicit is set by a Recorder on a prepare which database/sql
+	// made because the driver skipped this query or exec
+	implicit *FixtureCall
+}
+
+// FixtureArg is a recorded query argument, Name is only set for
+// named arguments.
+type FixtureArg struct {
+	Name  string       `json:"name,omitempty"`
+	Value FixtureValue `json:"value"`
+}
+
+// FixtureValue is a recorded driver.Value, which is written
+// together with its type so it is read back unchanged.
+type FixtureValue struct {
+	Value driver.Value
+}
+
+type fixtureValueJSON struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (v FixtureValue) MarshalJSON() ([]byte, error) {
+	var typ string
+	var val interface{}
+	switch x := v.Value.(type) {
+	case nil:
+		return json.Marshal(fixtureValueJSON{Type: "null"})
+	case int64:
+		typ, val = "int64", x
+	case float64:
+		typ, val = "float64", x
+	case bool:
+		typ, val = "bool", x
+	case []byte:
+		typ, val = "bytes", x
+	case string:
+		typ, val = "string", x
+	case time.Time:
+		typ, val = "time", x.Format(time.RFC3339Nano)
+	default:
+		return nil, fmt.Errorf("unsupported fixture value type %T", v.Value)
+	}
+
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fixtureValueJSON{Type: typ, Value: raw})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v *FixtureValue) UnmarshalJSON(data []byte) error {
+	var j fixtureValueJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	var err error
+	switch j.Type {
+	case "null":
+		v.Value = nil
+	case "int64":
+		var x int64
+		err = json.Unmarshal(j.Value, &x)
+		v.Value = x
+	case "float64":
+		var x float64
+		err = json.Unmarshal(j.Value, &x)
+		v.Value = x
+	case "bool":
+		var x bool
+		err = json.Unmarshal(j.Value, &x)
+		v.Value = x
+	case "bytes":
+		var x []byte
+		err = json.Unmarshal(j.Value, &x)
+		v.Value = x
+	case "string":
+		var x string
+		err = json.Unmarshal(j.Value, &x)
+		v.Value = x
+	case "time":
+		var s string
+		if err = json.Unmarshal(j.Value, &s); err == nil {
+			v.Value, err = time.Parse(time.RFC3339Nano, s)
+		}
+	default:
+		err = fmt.Errorf("unsupported fixture value type %q", j.Type)
+	}
+	return err
+}
+
+// LoadFixture reads a fixture file written by Recorder.WriteFile
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Fixture{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("could not parse fixture %s: %s", path, err)
+	}
+	return f, nil
+}
+
+// WriteFile writes the fixture as JSON to the file at path
+func (f *Fixture) WriteFile(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func (c *sqlmock) ExpectFixture(f *Fixture) error {
+	for i, call := range f.Calls {
+		var err error
+		if call.Error != "" {
+			err = errors.New(call.Error)
+		}
+
+		switch call.Op {
+		case FixtureBegin:
+			c.ExpectBegin().WillReturnError(err)
+		case FixtureCommit:
+			c.ExpectCommit().WillReturnError(err)
+		case FixtureRollback:
+			c.ExpectRollback().WillReturnError(err)
+		case FixturePrepare:
+			c.ExpectPrepare(c.fixtureSQL(call.Query)).WillReturnError(err)
+		case FixtureQuery:
+			e := c.ExpectQuery(c.fixtureSQL(call.Query)).WithArgs(fixtureArgs(call.Args)...)
+			if err != nil {
+				e.WillReturnError(err)
+				continue
+			}
+			rows := c.NewRows(call.Columns)
+			for _, row := range call.Rows {
+				values := make([]driver.Value, len(row))
+				for j, v := range row {
+					values[j] = v.Value
+				}
+				rows.AddRow(values...)
+			}
+			e.WillReturnRows(rows)
+		case FixtureExec:
+			e := c.ExpectExec(c.fixtureSQL(call.Query)).WithArgs(fixtureArgs(call.Args)...)
+			if err != nil {
+				e.WillReturnError(err)
+				continue
+			}
+			e.WillReturnResult(NewResult(call.LastInsertID, call.RowsAffected))
+		default:
+			return fmt.Errorf("fixture call %d has unknown operation %q", i, call.Op)
+		}
+	}
+	return nil
+}
+
+// fixtureSQL returns the expected SQL matching a recorded query with
+// the mock query matcher: the quoted query for the default regexp
+// matcher, and the query itself for the others.
+func (c *sqlmock) fixtureSQL(query string) string {
+	quoted := "^" + regexp.QuoteMeta(stripQuery(query)) + "$"
+	if c.queryMatcher.Match(quoted, query) == nil {
+		return quoted
+	}
+	return query
+}
+
+func fixtureArgs(args []FixtureArg) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		var v driver.Value = arg.Value.Value
+		if t, ok := v.(time.Time); ok {
+			v = timeArgument(t)
+		}
+		if arg.Name != "" {
+			v = sql.Named(arg.Name, v)
+		}
+		values[i] = v
+	}
+	return values
+}
+
+// timeArgument matches a time argument equal to a recorded one,
+// which may differ in location and monotonic clock reading.
+type timeArgument time.Time
+
+func (a timeArgument) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	return ok && t.Equal(time.Time(a))
+}
+
+func (a timeArgument) String() string {
+	return time.Time(a).Format(time.RFC3339Nano)
+}
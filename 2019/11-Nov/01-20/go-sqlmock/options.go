@@ -17,3 +17,13 @@ func QueryMatcherOption(queryMatcher QueryMatcher) func(*sqlmock) error {
 		return nil
 	}
 }
+
+// MonitorPingsOption determines whether calls to Ping on the driver should be
+// observed and mocked. If true, each ping must be expected with ExpectPing
+// and the mock no longer pings on open. Defaults to false.
+func MonitorPingsOption(monitorPings bool) func(*sqlmock) error {
+	return func(s *sqlmock) error {
+		s.monitorPings = monitorPings
+		return nil
+	}
+}
@@ -54,7 +54,7 @@ var QueryMatcherEqual QueryMatcher = QueryMatcherFunc(func(expectedSQL, actualSQ
 	expect := stripQuery(expectedSQL)
 	actual := stripQuery(actualSQL)
 	if actual != expect {
-		return fmt.Errorf(`actual sql: "%s" does not equal to expected "%s`, actual, expect)
+		return fmt.Errorf(`actual sql: "%s" does not equal to expected "%s"`, actual, expect)
 	}
 	return nil
 })
@@ -0,0 +1,254 @@
+package sqlmock
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// QueryMatcherNormalized is the SQL query matcher which
+// compares expected and actual SQL token by token. Before
+// comparing it ignores comments and whitespace, folds keywords
+// and identifiers to lower case, unquotes "quoted", `quoted`
+// and [quoted] identifiers and turns every placeholder style,
+// like ?, $1, :name and @name, into ?. On mismatch it reports
+// a token level diff.
+var QueryMatcherNormalized QueryMatcher = QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+	expect := tokenizeSQL(expectedSQL)
+	actual := tokenizeSQL(actualSQL)
+	if tokensEqual(expect, actual) {
+		return nil
+	}
+	return fmt.Errorf(`could not match actual sql: "%s" with expected normalized sql "%s", token diff: %s`,
+		joinTokens(actual), joinTokens(expect), diffTokens(expect, actual))
+})
+
+type sqlTokenKind int
+
+const (
+	sqlWord sqlTokenKind = iota // keywords and identifiers
+	sqlString
+	sqlNumber
+	sqlPlaceholder
+	sqlSymbol
+)
+
+type sqlToken struct {
+	kind  sqlTokenKind
+	value string
+}
+
+func (t sqlToken) String() string {
+	if t.kind == sqlString {
+		return "'" + strings.Replace(t.value, "'", "''", -1) + "'"
+	}
+	return t.value
+}
+
+// tokenizeSQL splits a query into normalized tokens. It is
+// deliberately lenient: unterminated strings, quotes and
+// comments run to the end of the query.
+func tokenizeSQL(sql string) []sqlToken {
+	var tokens []sqlToken
+	src := []rune(sql)
+	for i := 0; i < len(src); {
+		c := src[i]
+		next := rune(0)
+		if i+1 < len(src) {
+			next = src[i+1]
+		}
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '-' && next == '-':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '/' && next == '*':
+			end := indexRunes(src, i+2, "*/")
+			if end < 0 {
+				i = len(src)
+			} else {
+				i = end + 2
+			}
+		case c == '\'':
+			value, n := readQuoted(src[i:], '\'')
+			tokens = append(tokens, sqlToken{sqlString, value})
+			i += n
+		case c == '"' || c == '`':
+			value, n := readQuoted(src[i:], c)
+			tokens = append(tokens, sqlToken{sqlWord, strings.ToLower(value)})
+			i += n
+		case c == '[' && isBracketIdentifier(src[i:]):
+			end := indexRunes(src, i, "]")
+			tokens = append(tokens, sqlToken{sqlWord, strings.ToLower(string(src[i+1 : end]))})
+			i = end + 1
+		case c == '?':
+			tokens = append(tokens, sqlToken{sqlPlaceholder, "?"})
+			i++
+		case c == '$' && unicode.IsDigit(next):
+			i = skipRunes(src, i+1, unicode.IsDigit)
+			tokens = append(tokens, sqlToken{sqlPlaceholder, "?"})
+		case (c == ':' || c == '@') && isWordStart(next) && (i == 0 || src[i-1] != c):
+			i = skipRunes(src, i+1, isWordPart)
+			tokens = append(tokens, sqlToken{sqlPlaceholder, "?"})
+		case isWordStart(c):
+			end := skipRunes(src, i, isWordPart)
+			tokens = append(tokens, sqlToken{sqlWord, strings.ToLower(string(src[i:end]))})
+			i = end
+		case unicode.IsDigit(c) || (c == '.' && unicode.IsDigit(next)):
+			end := skipRunes(src, i, func(r rune) bool {
+				return r == '.' || isWordPart(r)
+			})
+			tokens = append(tokens, sqlToken{sqlNumber, strings.ToLower(string(src[i:end]))})
+			i = end
+		default:
+			value := string(c)
+			switch op := string([]rune{c, next}); op {
+			case "<=", ">=", "<>", "!=", "||", "::":
+				value = op
+			}
+			if value == "!=" {
+				value = "<>"
+			}
+			tokens = append(tokens, sqlToken{sqlSymbol, value})
+			i += len([]rune(value))
+		}
+	}
+	return tokens
+}
+
+// readQuoted reads a quoted string from the start of src, where a
+// doubled quote stands for the quote itself. It returns the unquoted
+// value and the number of runes read.
+func readQuoted(src []rune, quote rune) (string, int) {
+	var value []rune
+	i := 1
+	for i < len(src) {
+		if src[i] == quote {
+			if i+1 < len(src) && src[i+1] == quote {
+				value = append(value, quote)
+				i += 2
+				continue
+			}
+			return string(value), i + 1
+		}
+		value = append(value, src[i])
+		i++
+	}
+	return string(value), i
+}
+
+// isBracketIdentifier reports whether src starts with a [quoted]
+// identifier, rather than an array subscript or index.
+func isBracketIdentifier(src []rune) bool {
+	for i := 1; i < len(src); i++ {
+		switch {
+		case src[i] == ']':
+			return i > 1 && isWordStart(src[1])
+		case !isWordPart(src[i]) && src[i] != ' ':
+			return false
+		}
+	}
+	return false
+}
+
+func isWordStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isWordPart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func skipRunes(src []rune, i int, f func(rune) bool) int {
+	for i < len(src) && f(src[i]) {
+		i++
+	}
+	return i
+}
+
+func indexRunes(src []rune, from int, sep string) int {
+	if idx := strings.Index(string(src[from:]), sep); idx >= 0 {
+		return from + len([]rune(string(src[from:])[:idx]))
+	}
+	return -1
+}
+
+func tokensEqual(a, b []sqlToken) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func joinTokens(tokens []sqlToken) string {
+	strs := make([]string, len(tokens))
+	for i, t := range tokens {
+		strs[i] = t.String()
+	}
+	return strings.Join(strs, " ")
+}
+
+// diffTokens returns the tokens of both queries in the style of a
+// word diff, marking the expected tokens missing from actual as
+// [-token-] and the unexpected actual tokens as {+token+}.
+func diffTokens(expect, actual []sqlToken) string {
+	lcs := lcsTable(expect, actual)
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(expect) || j < len(actual) {
+		switch {
+		case i < len(expect) && j < len(actual) && expect[i] == actual[j]:
+			diff = append(diff, expect[i].String())
+			i++
+			j++
+		case j == len(actual) || (i < len(expect) && lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, "[-"+expect[i].String()+"-]")
+			i++
+		default:
+			diff = append(diff, "{+"+actual[j].String()+"+}")
+			j++
+		}
+	}
+	return strings.Join(diff, " ")
+}
+
+// lcsTable returns the lengths of the longest common subsequences
+// of all the token suffixes of a and b.
+func lcsTable(a, b []sqlToken) [][]int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	return lcs
+}
+
+// tokenSimilarity returns how similar two queries are, from 0 when
+// they have no token in common to 1 when their tokens are equal.
+func tokenSimilarity(a, b string) float64 {
+	ta, tb := tokenizeSQL(a), tokenizeSQL(b)
+	if len(ta)+len(tb) == 0 {
+		return 1
+	}
+	return float64(2*lcsTable(ta, tb)[0][0]) / float64(len(ta)+len(tb))
+}
@@ -0,0 +1,83 @@
+package sqlmock
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTokenizeSQL(t *testing.T) {
+	cases := []struct {
+		sql      string
+		expected string
+	}{
+		{"SELECT * FROM users", "select * from users"},
+		{"select  id,name\n\tFROM Users -- all of them\nWHERE id = ?", "select id , name from users where id = ?"},
+		{`SELECT "Name", ` + "`email`" + ` FROM [Order Details] /* comment */`, "select name , email from order details"},
+		{"UPDATE users SET name = 'O''Brien', Note = 'Hello' WHERE id = $1", "update users set name = 'O''Brien' , note = 'Hello' where id = ?"},
+		{"SELECT * FROM t WHERE a = :a AND b = @b AND c = $12", "select * from t where a = ? and b = ? and c = ?"},
+		{"SELECT x::int FROM t WHERE a != 1.5 AND b <= 2", "select x :: int from t where a <> 1.5 and b <= 2"},
+		{"SELECT arr[1] FROM t", "select arr [ 1 ] from t"},
+		{"SELECT 'unterminated", "select 'unterminated'"},
+	}
+
+	for i, c := range cases {
+		if res := joinTokens(tokenizeSQL(c.sql)); res != c.expected {
+			t.Errorf(`expected tokens "%s" at %d case, but got "%s"`, c.expected, i, res)
+		}
+	}
+}
+
+func TestQueryMatcherNormalized(t *testing.T) {
+	type testCase struct {
+		expected string
+		actual   string
+		err      error
+	}
+
+	cases := []testCase{
+		{"SELECT name FROM users WHERE id = ?", "select Name\n from `users` where ID = $1", nil},
+		{"INSERT INTO users (name) VALUES (:name) -- create", `/* create */ insert into "users" ( name ) values ( @name )`, nil},
+		{"SELECT name FROM users WHERE name = 'John'", "SELECT name FROM users WHERE name = 'john'", fmt.Errorf(`could not match actual sql: "select name from users where name = 'john'" with expected normalized sql "select name from users where name = 'John'", token diff: select name from users where name = [-'John'-] {+'john'+}`)},
+		{"SELECT id FROM users WHERE id = ?", "SELECT id, name FROM users", fmt.Errorf(`could not match actual sql: "select id , name from users" with expected normalized sql "select id from users where id = ?", token diff: select id {+,+} {+name+} from users [-where-] [-id-] [-=-] [-?-]`)},
+	}
+
+	for i, c := range cases {
+		err := QueryMatcherNormalized.Match(c.expected, c.actual)
+		if err == nil && c.err != nil {
+			t.Errorf(`got no error, but expected "%v" at %d case`, c.err, i)
+			continue
+		}
+		if err != nil && c.err == nil {
+			t.Errorf(`got unexpected error "%v" at %d case`, err, i)
+			continue
+		}
+		if err == nil {
+			continue
+		}
+		if err.Error() != c.err.Error() {
+			t.Errorf(`expected error "%v", but got "%v" at %d case`, c.err, err, i)
+		}
+	}
+}
+
+func TestQueryMatcherNormalizedOption(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(QueryMatcherOption(QueryMatcherNormalized))
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE users SET name = ? WHERE id = ?").
+		WithArgs("john", 5).
+		WillReturnResult(NewResult(0, 1))
+
+	_, err = db.Exec(`update "users" set "name" = $1 where "id" = $2`, "john", 5)
+	if err != nil {
+		t.Errorf("error was not expected, but got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -75,6 +75,9 @@ func TestQueryMatcherRegexp(t *testing.T) {
 			t.Errorf(`got unexpected error "%v" at %d case`, err, i)
 			continue
 		}
+		if err == nil {
+			continue
+		}
 		if err.Error() != c.err.Error() {
 			t.Errorf(`expected error "%v", but got "%v" at %d case`, c.err, err, i)
 		}
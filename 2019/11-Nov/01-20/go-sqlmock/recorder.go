@@ -0,0 +1,392 @@
+//go:build go1.8
+// +build go1.8
+
+package sqlmock
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync"
+)
+
+// Recorder is a database/sql driver which records every Prepare,
+// Query, Exec, Begin, Commit and Rollback passed to the driver it
+// wraps, with their arguments, returned rows and errors, so the
+// calls made against a real database can be written to a fixture
+// and replayed with Sqlmock.ExpectFixture:
+//
+//	rec := sqlmock.NewRecorder(&pq.Driver{})
+//	sql.Register("postgres-recorder", rec)
+//	db, err := sql.Open("postgres-recorder", dsn)
+//	// ... run the code under test against db
+//	err = rec.WriteFile("testdata/dao.json")
+//
+// Only the rows which were read are recorded, and only from the
+// first result set. When the wrapped driver skips a query or exec,
+// so database/sql prepares it instead, it is recorded as the query
+// or exec itself, the way it reaches Sqlmock.
+type Recorder struct {
+	drv     driver.Driver
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// NewRecorder creates a Recorder wrapping drv
+func NewRecorder(drv driver.Driver) *Recorder {
+	return &Recorder{drv: drv}
+}
+
+// Open implements driver.Driver, opening a connection with the
+// wrapped driver
+func (r *Recorder) Open(name string) (driver.Conn, error) {
+	conn, err := r.drv.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingConn{rec: r, conn: conn}, nil
+}
+
+// Fixture returns a copy of the calls recorded so far
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f := &Fixture{Calls: make([]*FixtureCall, 0, len(r.fixture.Calls))}
+	for _, call := range r.fixture.Calls {
+		// an implicit prepare is folded into the query or exec it
+		// was made for, which is recorded after it unless the
+		// prepare failed
+		if call.implicit != nil {
+			if call.Error == "" {
+				continue
+			}
+			c := *call.implicit
+			c.Error = call.Error
+			f.Calls = append(f.Calls, &c)
+			continue
+		}
+
+		c := *call
+		c.Rows = append([][]FixtureValue(nil), call.Rows...)
+		f.Calls = append(f.Calls, &c)
+	}
+	return f
+}
+
+// WriteFile writes the calls recorded so far as a fixture file,
+// which can be read back with LoadFixture
+func (r *Recorder) WriteFile(path string) error {
+	return r.Fixture().WriteFile(path)
+}
+
+func (r *Recorder) record(call *FixtureCall) {
+	r.mu.Lock()
+	r.fixture.Calls = append(r.fixture.Calls, call)
+	r.mu.Unlock()
+}
+
+func (r *Recorder) recordQuery(query string, args []driver.NamedValue, rows driver.Rows, err error) (driver.Rows, error) {
+	call := &FixtureCall{Op: FixtureQuery, Query: query, Args: recordArgs(args), Error: errString(err)}
+	if err == nil {
+		call.Columns = rows.Columns()
+	}
+	r.record(call)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingRows{rec: r, rows: rows, call: call}, nil
+}
+
+func (r *Recorder) recordExec(query string, args []driver.NamedValue, res driver.Result, err error) (driver.Result, error) {
+	call := &FixtureCall{Op: FixtureExec, Query: query, Args: recordArgs(args), Error: errString(err)}
+	if err == nil {
+		call.LastInsertID, _ = res.LastInsertId()
+		call.RowsAffected, _ = res.RowsAffected()
+	}
+	r.record(call)
+	return res, err
+}
+
+type recordingConn struct {
+	rec  *Recorder
+	conn driver.Conn
+
+	// skipped is the query or exec the driver skipped last, which
+	// database/sql prepares next on the same connection
+	skipped *FixtureCall
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *recordingConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if pc, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = pc.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+
+	call := &FixtureCall{Op: FixturePrepare, Query: query, Error: errString(err)}
+	if c.skipped != nil && c.skipped.Query == query {
+		call.implicit = c.skipped
+	}
+	c.skipped = nil
+
+	c.rec.record(call)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingStmt{conn: c, stmt: stmt, query: query}, nil
+}
+
+func (c *recordingConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *recordingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var tx driver.Tx
+	var err error
+	if bt, ok := c.conn.(driver.ConnBeginTx); ok {
+		tx, err = bt.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.conn.Begin()
+	}
+
+	c.rec.record(&FixtureCall{Op: FixtureBegin, Error: errString(err)})
+	if err != nil {
+		return nil, err
+	}
+	return &recordingTx{rec: c.rec, tx: tx}, nil
+}
+
+func (c *recordingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	var rows driver.Rows
+	var err error
+	switch q := c.conn.(type) {
+	case driver.QueryerContext:
+		rows, err = q.QueryContext(ctx, query, args)
+	case driver.Queryer:
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			rows, err = q.Query(query, values)
+		}
+	default:
+		err = driver.ErrSkip
+	}
+
+	// the query is prepared instead, and recorded as the query
+	if err == driver.ErrSkip {
+		c.skipped = &FixtureCall{Op: FixtureQuery, Query: query, Args: recordArgs(args)}
+		return nil, err
+	}
+	return c.rec.recordQuery(query, args, rows, err)
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	var res driver.Result
+	var err error
+	switch e := c.conn.(type) {
+	case driver.ExecerContext:
+		res, err = e.ExecContext(ctx, query, args)
+	case driver.Execer:
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			res, err = e.Exec(query, values)
+		}
+	default:
+		err = driver.ErrSkip
+	}
+
+	// the exec is prepared instead, and recorded as the exec
+	if err == driver.ErrSkip {
+		c.skipped = &FixtureCall{Op: FixtureExec, Query: query, Args: recordArgs(args)}
+		return nil, err
+	}
+	return c.rec.recordExec(query, args, res, err)
+}
+
+func (c *recordingConn) Ping(ctx context.Context) error {
+	if p, ok := c.conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *recordingConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type recordingStmt struct {
+	conn  *recordingConn
+	stmt  driver.Stmt
+	query string
+}
+
+func (s *recordingStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *recordingStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), valuesToNamedValues(args))
+}
+
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), valuesToNamedValues(args))
+}
+
+func (s *recordingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	var res driver.Result
+	var err error
+	if sc, ok := s.stmt.(driver.StmtExecContext); ok {
+		res, err = sc.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			res, err = s.stmt.Exec(values)
+		}
+	}
+	return s.conn.rec.recordExec(s.query, args, res, err)
+}
+
+func (s *recordingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	var rows driver.Rows
+	var err error
+	if sc, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err = sc.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			rows, err = s.stmt.Query(values)
+		}
+	}
+	return s.conn.rec.recordQuery(s.query, args, rows, err)
+}
+
+func (s *recordingStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return s.conn.CheckNamedValue(nv)
+}
+
+func (s *recordingStmt) ColumnConverter(idx int) driver.ValueConverter {
+	if cc, ok := s.stmt.(driver.ColumnConverter); ok {
+		return cc.ColumnConverter(idx)
+	}
+	return driver.DefaultParameterConverter
+}
+
+type recordingTx struct {
+	rec *Recorder
+	tx  driver.Tx
+}
+
+func (t *recordingTx) Commit() error {
+	err := t.tx.Commit()
+	t.rec.record(&FixtureCall{Op: FixtureCommit, Error: errString(err)})
+	return err
+}
+
+func (t *recordingTx) Rollback() error {
+	err := t.tx.Rollback()
+	t.rec.record(&FixtureCall{Op: FixtureRollback, Error: errString(err)})
+	return err
+}
+
+type recordingRows struct {
+	rec  *Recorder
+	rows driver.Rows
+	call *FixtureCall
+}
+
+func (r *recordingRows) Columns() []string {
+	return r.rows.Columns()
+}
+
+func (r *recordingRows) Close() error {
+	return r.rows.Close()
+}
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	err := r.rows.Next(dest)
+	if err != nil {
+		return err
+	}
+
+	row := make([]FixtureValue, len(dest))
+	for i, v := range dest {
+		row[i] = recordValue(v)
+	}
+	r.rec.mu.Lock()
+	r.call.Rows = append(r.call.Rows, row)
+	r.rec.mu.Unlock()
+	return nil
+}
+
+func recordArgs(args []driver.NamedValue) []FixtureArg {
+	if len(args) == 0 {
+		return nil
+	}
+	recorded := make([]FixtureArg, len(args))
+	for i, arg := range args {
+		recorded[i] = FixtureArg{Name: arg.Name, Value: recordValue(arg.Value)}
+	}
+	return recorded
+}
+
+// recordValue copies a driver value, as []byte values may be reused
+// by the driver, and converts values of other types than the ones
+// defined by the driver package.
+func recordValue(v driver.Value) FixtureValue {
+	switch x := v.(type) {
+	case []byte:
+		return FixtureValue{append([]byte(nil), x...)}
+	case nil, int64, float64, bool, string:
+		return FixtureValue{x}
+	}
+	if converted, err := driver.DefaultParameterConverter.ConvertValue(v); err == nil {
+		return FixtureValue{converted}
+	}
+	return FixtureValue{v}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func valuesToNamedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("sqlmock: driver does not support the use of Named Parameters")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
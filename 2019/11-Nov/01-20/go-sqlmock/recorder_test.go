@@ -0,0 +1,304 @@
+//go:build go1.8
+// +build go1.8
+
+package sqlmock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var recorder *Recorder
+
+var skippingRecorder *Recorder
+
+func init() {
+	recorder = NewRecorder(pool)
+	sql.Register("sqlmock_recorder", recorder)
+	skippingRecorder = NewRecorder(skippingDriver{pool})
+	sql.Register("sqlmock_skipping_recorder", skippingRecorder)
+}
+
+// skippingDriver skips direct queries and execs, like drivers which
+// only support them through prepared statements
+type skippingDriver struct {
+	drv driver.Driver
+}
+
+func (d skippingDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.drv.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return skippingConn{conn}, nil
+}
+
+type skippingConn struct {
+	driver.Conn
+}
+
+func (c skippingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c skippingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+
+type article struct {
+	id        int64
+	title     string
+	body      []byte
+	createdAt time.Time
+}
+
+// articlesDAO runs the calls which are recorded and replayed
+func articlesDAO(db *sql.DB, created time.Time) ([]article, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec("INSERT INTO articles(title, body, created_at) VALUES (?, ?, ?)", "hello", []byte("world"), created)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	stmt, err := tx.Prepare("SELECT id, title, body, created_at FROM articles WHERE title = :title")
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(sql.Named("title", "hello"))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	defer rows.Close()
+
+	var articles []article
+	for rows.Next() {
+		var a article
+		if err := rows.Scan(&a.id, &a.title, &a.body, &a.createdAt); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		articles = append(articles, a)
+	}
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM drafts"); err == nil {
+		return nil, fmt.Errorf("expected an error deleting drafts")
+	}
+	return articles, tx.Commit()
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	created := time.Date(2019, 11, 20, 10, 30, 0, 0, time.UTC)
+
+	// the database the calls are recorded against
+	real, mock, err := NewWithDSN("sqlmock_db_recorded")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer real.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO articles").
+		WithArgs("hello", []byte("world"), created).
+		WillReturnResult(NewResult(5, 1))
+	mock.ExpectPrepare("SELECT (.+) FROM articles").
+		ExpectQuery().
+		WithArgs(sql.Named("title", "hello")).
+		WillReturnRows(NewRows([]string{"id", "title", "body", "created_at"}).
+			AddRow(5, "hello", []byte("world"), created))
+	mock.ExpectExec("DELETE FROM drafts").WillReturnError(fmt.Errorf("drafts are locked"))
+	mock.ExpectCommit()
+
+	db, err := sql.Open("sqlmock_recorder", "sqlmock_db_recorded")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening the recorder", err)
+	}
+	recorded, err := articlesDAO(db, created)
+	if err != nil {
+		t.Fatalf("error was not expected while recording, but got: %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations while recording: %s", err)
+	}
+
+	dir, err := ioutil.TempDir("", "sqlmock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "articles.json")
+	if err := recorder.WriteFile(path); err != nil {
+		t.Fatalf("error was not expected while writing the fixture, but got: %s", err)
+	}
+
+	fixture, err := LoadFixture(path)
+	if err != nil {
+		t.Fatalf("error was not expected while loading the fixture, but got: %s", err)
+	}
+	if !reflect.DeepEqual(fixture, recorder.Fixture()) {
+		t.Errorf("expected the loaded fixture to equal the recorded one")
+	}
+
+	// replay the fixture against a new mock
+	replay, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer replay.Close()
+
+	if err := mock.ExpectFixture(fixture); err != nil {
+		t.Fatalf("error was not expected while replaying the fixture, but got: %s", err)
+	}
+
+	replayed, err := articlesDAO(replay, created)
+	if err != nil {
+		t.Fatalf("error was not expected while replaying, but got: %s", err)
+	}
+	if !reflect.DeepEqual(replayed, recorded) {
+		t.Errorf("expected replayed articles %v to equal the recorded %v", replayed, recorded)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations while replaying: %s", err)
+	}
+}
+
+func TestReplayWithQueryMatchers(t *testing.T) {
+	fixture := &Fixture{Calls: []*FixtureCall{
+		{Op: FixtureExec, Query: "UPDATE users SET name = ? WHERE id = ?", Args: []FixtureArg{
+			{Value: FixtureValue{"john"}},
+			{Value: FixtureValue{int64(5)}},
+		}, RowsAffected: 1},
+	}}
+
+	for _, matcher := range []QueryMatcher{QueryMatcherRegexp, QueryMatcherEqual, QueryMatcherNormalized} {
+		db, mock, err := New(QueryMatcherOption(matcher))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		if err := mock.ExpectFixture(fixture); err != nil {
+			t.Errorf("error was not expected while replaying the fixture, but got: %s", err)
+		}
+		if _, err := db.Exec("UPDATE users SET name = ? WHERE id = ?", "john", 5); err != nil {
+			t.Errorf("error was not expected, but got: %s", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+		db.Close()
+	}
+}
+
+func TestReplayUnknownOperation(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	err = mock.ExpectFixture(&Fixture{Calls: []*FixtureCall{{Op: "truncate"}}})
+	if err == nil || err.Error() != `fixture call 0 has unknown operation "truncate"` {
+		t.Errorf("expected an unknown operation error, but got: %v", err)
+	}
+}
+
+func TestRecordSkippedQueriesAsPrepared(t *testing.T) {
+	real, mock, err := NewWithDSN("sqlmock_db_skipping")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer real.Close()
+
+	mock.ExpectPrepare("SELECT title FROM articles").
+		ExpectQuery().
+		WithArgs(5).
+		WillReturnRows(NewRows([]string{"title"}).AddRow("hello"))
+	mock.ExpectPrepare("UPDATE articles").
+		ExpectExec().
+		WithArgs("bye", 5).
+		WillReturnResult(NewResult(0, 1))
+	mock.ExpectPrepare("DELETE FROM drafts").WillReturnError(fmt.Errorf("drafts are locked"))
+
+	run := func(db *sql.DB) (string, error) {
+		var title string
+		if err := db.QueryRow("SELECT title FROM articles WHERE id = ?", 5).Scan(&title); err != nil {
+			return "", err
+		}
+		if _, err := db.Exec("UPDATE articles SET title = ? WHERE id = ?", "bye", 5); err != nil {
+			return "", err
+		}
+		if _, err := db.Exec("DELETE FROM drafts"); err == nil || err.Error() != "drafts are locked" {
+			return "", fmt.Errorf("expected an error deleting drafts, but got: %v", err)
+		}
+		return title, nil
+	}
+
+	db, err := sql.Open("sqlmock_skipping_recorder", "sqlmock_db_skipping")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening the recorder", err)
+	}
+	defer db.Close()
+
+	recorded, err := run(db)
+	if err != nil {
+		t.Fatalf("error was not expected while recording, but got: %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations while recording: %s", err)
+	}
+
+	fixture := skippingRecorder.Fixture()
+	var ops []string
+	for _, call := range fixture.Calls {
+		ops = append(ops, call.Op)
+	}
+	if expected := []string{FixtureQuery, FixtureExec, FixtureExec}; !reflect.DeepEqual(ops, expected) {
+		t.Fatalf("expected the implicit prepares to be folded into %v, but got: %v", expected, ops)
+	}
+	if fixture.Calls[2].Error != "drafts are locked" {
+		t.Errorf("expected the failed prepare to be recorded on the exec, but got: %+v", fixture.Calls[2])
+	}
+
+	// replay in order against a mock queried directly
+	replay, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer replay.Close()
+
+	if err := mock.ExpectFixture(fixture); err != nil {
+		t.Fatalf("error was not expected while replaying the fixture, but got: %s", err)
+	}
+	replayed, err := run(replay)
+	if err != nil {
+		t.Fatalf("error was not expected while replaying, but got: %s", err)
+	}
+	if replayed != recorded {
+		t.Errorf("expected replayed title %q to equal the recorded %q", replayed, recorded)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations while replaying: %s", err)
+	}
+}
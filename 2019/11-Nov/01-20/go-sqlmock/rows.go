@@ -26,6 +26,8 @@ type rowSets struct {
 	pos  int
 	ex   *ExpectedQuery
 	raw  [][]byte
+
+	closed bool
 }
 
 func (rs *rowSets) Columns() []string {
@@ -34,7 +36,12 @@ func (rs *rowSets) Columns() []string {
 
 func (rs *rowSets) Close() error {
 	rs.invalidateRaw()
-	rs.ex.rowsWereClosed = true
+	if !rs.closed {
+		rs.closed = true
+		rs.ex.Lock()
+		rs.ex.rowsClosed++
+		rs.ex.Unlock()
+	}
 	return rs.sets[rs.pos].closeErr
 }
 
@@ -78,6 +85,18 @@ func (rs *rowSets) String() string {
 	return strings.TrimSpace(msg)
 }
 
+// clone returns the row sets read from the start, so the rows of a
+// query expected more than once are returned in full each time.
+func (rs *rowSets) clone() *rowSets {
+	sets := make([]*Rows, len(rs.sets))
+	for i, set := range rs.sets {
+		r := *set
+		r.pos = 0
+		sets[i] = &r
+	}
+	return &rowSets{sets: sets, ex: rs.ex}
+}
+
 func (rs *rowSets) empty() bool {
 	for _, set := range rs.sets {
 		if len(set.rows) > 0 {
@@ -110,6 +129,7 @@ func (rs *rowSets) invalidateRaw() {
 type Rows struct {
 	converter driver.ValueConverter
 	cols      []string
+	def       []*Column
 	rows      [][]driver.Value
 	pos       int
 	nextErr   map[int]error
@@ -119,8 +139,29 @@ type Rows struct {
 // NewRows allows Rows to be created from a sql driver.Value slice or from the CSV string
 // and to be used as sql driver.Rows. Use Sqlmock.NewRows instead of custom converter
 func NewRows(columns []string) *Rows {
+	def := make([]*Column, len(columns))
+	for i, name := range columns {
+		def[i] = NewColumn(name)
+	}
 	return &Rows{
 		cols:      columns,
+		def:       def,
+		nextErr:   make(map[int]error),
+		converter: driver.DefaultParameterConverter,
+	}
+}
+
+// NewRowsWithColumnDefinition allows Rows to be created from columns declaring
+// their type metadata, which is returned by *sql.Rows.ColumnTypes. Use
+// Sqlmock.NewRowsWithColumnDefinition instead of custom converter
+func NewRowsWithColumnDefinition(columns ...*Column) *Rows {
+	cols := make([]string, len(columns))
+	for i, column := range columns {
+		cols[i] = column.Name()
+	}
+	return &Rows{
+		cols:      cols,
+		def:       columns,
 		nextErr:   make(map[int]error),
 		converter: driver.DefaultParameterConverter,
 	}
@@ -133,6 +174,7 @@ func (r *Rows) CloseError(err error) *Rows {
 }
 
 // RowError allows to set an error which will be returnd when a given row number is read
+// from these rows, which may be one of several result sets.
 func (r *Rows) RowError(row int, err error) *Rows {
 	r.nextErr[row] = err
 	return r
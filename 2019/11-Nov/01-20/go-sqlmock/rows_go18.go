@@ -0,0 +1,54 @@
+//go:build go1.8
+// +build go1.8
+
+package sqlmock
+
+import (
+	"io"
+	"reflect"
+)
+
+// Implement the "RowsNextResultSet" interface
+func (rs *rowSets) HasNextResultSet() bool {
+	return rs.pos+1 < len(rs.sets)
+}
+
+// Implement the "RowsNextResultSet" interface
+func (rs *rowSets) NextResultSet() error {
+	if !rs.HasNextResultSet() {
+		return io.EOF
+	}
+
+	rs.invalidateRaw()
+	rs.pos++
+	return nil
+}
+
+// Implement the "RowsColumnTypeDatabaseTypeName" interface
+func (rs *rowSets) ColumnTypeDatabaseTypeName(index int) string {
+	return rs.getDefinition(index).DbType()
+}
+
+// Implement the "RowsColumnTypeLength" interface
+func (rs *rowSets) ColumnTypeLength(index int) (int64, bool) {
+	return rs.getDefinition(index).Length()
+}
+
+// Implement the "RowsColumnTypeNullable" interface
+func (rs *rowSets) ColumnTypeNullable(index int) (bool, bool) {
+	return rs.getDefinition(index).IsNullable()
+}
+
+// Implement the "RowsColumnTypePrecisionScale" interface
+func (rs *rowSets) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	return rs.getDefinition(index).PrecisionScale()
+}
+
+// Implement the "RowsColumnTypeScanType" interface
+func (rs *rowSets) ColumnTypeScanType(index int) reflect.Type {
+	return rs.getDefinition(index).ScanType()
+}
+
+func (rs *rowSets) getDefinition(index int) *Column {
+	return rs.sets[rs.pos].def[index]
+}
@@ -0,0 +1,195 @@
+//go:build go1.8
+// +build go1.8
+
+package sqlmock
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestQueryMultiRows(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rs1 := NewRows([]string{"id", "title"}).AddRow(5, "hello world")
+	rs2 := NewRows([]string{"name"}).AddRow("gopher").AddRow("john").AddRow("jane").RowError(2, fmt.Errorf("error"))
+
+	mock.ExpectQuery("SELECT (.+) FROM articles WHERE id = \\?;SELECT name FROM users").
+		WithArgs(5).
+		WillReturnRows(rs1, rs2)
+
+	rows, err := db.Query("SELECT id, title FROM articles WHERE id = ?;SELECT name FROM users", 5)
+	if err != nil {
+		t.Errorf("error was not expected, but got: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Error("expected a row in the first result set, but there was none")
+	}
+
+	var id int
+	var title string
+	if err := rows.Scan(&id, &title); err != nil {
+		t.Errorf("error '%s' was not expected while scanning row", err)
+	}
+	if id != 5 || title != "hello world" {
+		t.Errorf("expected row (5, hello world), but got: (%d, %s)", id, title)
+	}
+
+	if rows.Next() {
+		t.Error("expected no more rows in the first result set")
+	}
+
+	if !rows.NextResultSet() {
+		t.Error("expected more result sets", rows.Err())
+	}
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Errorf("error '%s' was not expected while scanning row", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err == nil {
+		t.Error("expected an error from the third row of the second result set")
+	}
+	if exp := []string{"gopher", "john"}; fmt.Sprint(names) != fmt.Sprint(exp) {
+		t.Errorf("expected names %v before the row error, but got: %v", exp, names)
+	}
+
+	if rows.NextResultSet() {
+		t.Error("expected no more result sets")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestQueryMultiRowsColumns(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rs1 := NewRows([]string{"id", "title"})
+	rs2 := NewRows([]string{"name"}).AddRow("gopher")
+
+	mock.ExpectQuery("CALL list_articles").WillReturnRows(rs1, rs2)
+
+	rows, err := db.Query("CALL list_articles()")
+	if err != nil {
+		t.Errorf("error was not expected, but got: %v", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		t.Error("expected the first result set to be empty")
+	}
+	if !rows.NextResultSet() {
+		t.Fatal("expected more result sets", rows.Err())
+	}
+
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Errorf("error was not expected, but got: %v", err)
+	}
+	if len(cols) != 1 || cols[0] != "name" {
+		t.Errorf("expected the columns of the second result set, but got: %v", cols)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestQueryRowsColumnTypes(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rs := mock.NewRowsWithColumnDefinition(
+		NewColumn("id").OfType("BIGINT", int64(0)).Nullable(false),
+		NewColumn("title").OfType("VARCHAR", "").Nullable(true).WithLength(255),
+		NewColumn("price").OfType("DECIMAL", float64(0)).WithPrecisionAndScale(10, 2),
+	).AddRow(5, "hello world", 9.99)
+
+	mock.ExpectQuery("SELECT (.+) FROM articles").WillReturnRows(rs)
+
+	rows, err := db.Query("SELECT id, title, price FROM articles")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %v", err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %v", err)
+	}
+	if len(types) != 3 {
+		t.Fatalf("expected 3 column types, but got: %d", len(types))
+	}
+
+	if types[0].Name() != "id" || types[0].DatabaseTypeName() != "BIGINT" {
+		t.Errorf("unexpected first column: %s %s", types[0].Name(), types[0].DatabaseTypeName())
+	}
+	if nullable, ok := types[0].Nullable(); nullable || !ok {
+		t.Errorf("expected 'id' column not to be nullable, but got: %t, %t", nullable, ok)
+	}
+	if types[0].ScanType() != reflect.TypeOf(int64(0)) {
+		t.Errorf("expected 'id' column scan type int64, but got: %v", types[0].ScanType())
+	}
+	if length, ok := types[1].Length(); length != 255 || !ok {
+		t.Errorf("expected 'title' column length 255, but got: %d, %t", length, ok)
+	}
+	if precision, scale, ok := types[2].DecimalSize(); precision != 10 || scale != 2 || !ok {
+		t.Errorf("expected 'price' column decimal size (10, 2), but got: %d, %d, %t", precision, scale, ok)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestQueryRowsColumnTypesWithoutDefinition(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id"}).AddRow(1))
+
+	rows, err := db.Query("SELECT id FROM articles")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %v", err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %v", err)
+	}
+	if types[0].Name() != "id" || types[0].DatabaseTypeName() != "" {
+		t.Errorf("unexpected column: %s %s", types[0].Name(), types[0].DatabaseTypeName())
+	}
+	if _, ok := types[0].Nullable(); ok {
+		t.Error("expected nullability to be unknown")
+	}
+}
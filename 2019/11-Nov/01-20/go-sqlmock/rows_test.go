@@ -304,7 +304,7 @@ func TestQueryRowBytesNotInvalidatedByNext_bytesIntoBytes(t *testing.T) {
 	t.Parallel()
 	rows := NewRows([]string{"raw"}).
 		AddRow([]byte(`one binary value with some text!`)).
-		AddRow([]byte(`two binary value with some more text than the first one`))
+		AddRow([]byte(`two binary value with even more text than the first one`))
 	scan := func(rs *sql.Rows) ([]byte, error) {
 		var b []byte
 		return b, rs.Scan(&b)
@@ -317,7 +317,7 @@ func TestQueryRowBytesNotInvalidatedByNext_stringIntoBytes(t *testing.T) {
 	t.Parallel()
 	rows := NewRows([]string{"raw"}).
 		AddRow(`one binary value with some text!`).
-		AddRow(`two binary value with some error text than the first one`)
+		AddRow(`two binary value with even more text than the first one`)
 	scan := func(rs *sql.Rows) ([]byte, error) {
 		var b []byte
 		return b, rs.Scan(&b)
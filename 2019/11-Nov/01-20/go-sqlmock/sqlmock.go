@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 )
 
@@ -36,6 +38,14 @@ type Sqlmock interface {
 	// ExpectRollback expects *sql.Tx.Rollback to be called.
 	ExpectRollback() *ExpectedRollback
 
+	// ExpectPing expects *sql.DB.Ping to be called. It only has an
+	// effect when pings are monitored, see MonitorPingsOption.
+	ExpectPing() *ExpectedPing
+
+	// ExpectFixture queues the calls recorded in the fixture as
+	// expectations, in the order they were recorded.
+	ExpectFixture(f *Fixture) error
+
 	// MatchExpectationsInOrder gives an option whether to match all
 	// expectations in the order they were set or not.
 	MatchExpectationsInOrder(bool)
@@ -43,6 +53,10 @@ type Sqlmock interface {
 	// NewRows allows Rows to be created from a sql driver.Value slice or from the CSV string
 	// and to  be used sql driver.Rows.
 	NewRows(columns []string) *Rows
+
+	// NewRowsWithColumnDefinition allows Rows to be created from columns
+	// declaring their type metadata, to be used as sql driver.Rows.
+	NewRowsWithColumnDefinition(columns ...*Column) *Rows
 }
 
 type sqlmock struct {
@@ -52,8 +66,12 @@ type sqlmock struct {
 	drv          *mockDriver
 	converter    driver.ValueConverter
 	queryMatcher QueryMatcher
+	monitorPings bool
 
 	expected []expectation
+
+	callsMu sync.Mutex
+	calls   []Call
 }
 
 func (c *sqlmock) open(options []func(*sqlmock) error) (*sql.DB, Sqlmock, error) {
@@ -73,7 +91,12 @@ func (c *sqlmock) open(options []func(*sqlmock) error) (*sql.DB, Sqlmock, error)
 	if c.queryMatcher == nil {
 		c.queryMatcher = QueryMatcherRegexp
 	}
-	return db, c, db.Ping()
+	// ping to open the connection, unless pings are monitored and
+	// must be expected by the caller
+	if !c.monitorPings {
+		err = db.Ping()
+	}
+	return db, c, err
 }
 
 func (c *sqlmock) ExpectClose() *ExpectedClose {
@@ -88,7 +111,10 @@ func (c *sqlmock) MatchExpectationsInOrder(b bool) {
 
 // Close a mock database driver connetion. It may or may not be called depending on the circumstances,
 // but if it is called there must be an *ExpectedClose expectation satistied.
-func (c *sqlmock) Close() error {
+func (c *sqlmock) Close() (err error) {
+	var matched expectation
+	defer func() { c.called("Close", "", nil, matched, err) }()
+
 	c.drv.Lock()
 	defer c.drv.Unlock()
 
@@ -102,7 +128,7 @@ func (c *sqlmock) Close() error {
 	var ok bool
 	for _, next := range c.expected {
 		next.Lock()
-		if next.fulfilled() {
+		if next.exhausted() {
 			next.Unlock()
 			fulfilled++
 			continue
@@ -112,8 +138,9 @@ func (c *sqlmock) Close() error {
 			break
 		}
 
+		optional := next.fulfilled()
 		next.Unlock()
-		if c.ordered {
+		if c.ordered && !optional {
 			return fmt.Errorf("call to database Close, was not expected, next expectation is : %s", next)
 		}
 	}
@@ -126,36 +153,50 @@ func (c *sqlmock) Close() error {
 		return fmt.Errorf(msg)
 	}
 
-	expected.triggered = true
+	expected.trigger()
+	matched = expected
 	expected.Unlock()
 	return expected.err
 }
 
 func (c *sqlmock) ExpectationsWereMet() error {
-	for _, e := range c.expected {
+	var unmet []UnmetExpectation
+	for i, e := range c.expected {
 		e.Lock()
 		fulfilled := e.fulfilled()
 		e.Unlock()
 
 		if !fulfilled {
-			return fmt.Errorf("there is a remaining expectation which was not matched: %s", e)
+			unmet = append(unmet, UnmetExpectation{i, e.String(), unmetReason(e)})
+			continue
 		}
 
 		// for expected prepared statement chek whether it was closed if expected.
 		if prep, ok := e.(*ExpectedPrepare); ok {
 			if prep.mustBeClosed && !prep.wasClosed {
-				return fmt.Errorf("expected prepared statement to be closed, but it was not: %s", prep)
+				unmet = append(unmet, UnmetExpectation{i, e.String(), "prepared statement was not closed"})
 			}
 		}
 
-		// must check whether all expected quried rows are closed
+		// must check whether all expected quried rows are closed,
+		// each time the query was triggered
 		if query, ok := e.(*ExpectedQuery); ok {
-			if query.rowsMustBeClosed && !query.rowsWereClosed {
-				return fmt.Errorf("expected query rows to be closed, but it was not: %s", query)
+			query.Lock()
+			unclosed := query.rowsClosed < query.rowsReturned
+			query.Unlock()
+			if query.rowsMustBeClosed && unclosed {
+				unmet = append(unmet, UnmetExpectation{i, e.String(), "query rows were not closed"})
 			}
 		}
 	}
-	return nil
+
+	if len(unmet) == 0 {
+		return nil
+	}
+
+	c.callsMu.Lock()
+	defer c.callsMu.Unlock()
+	return &ExpectationsError{Unmet: unmet, Calls: append([]Call(nil), c.calls...)}
 }
 
 func (c *sqlmock) Begin() (driver.Tx, error) {
@@ -169,13 +210,16 @@ func (c *sqlmock) Begin() (driver.Tx, error) {
 	return c, nil
 }
 
-func (c *sqlmock) begin() (*ExpectedBegin, error) {
+func (c *sqlmock) begin() (_ *ExpectedBegin, err error) {
+	var matched expectation
+	defer func() { c.called("Begin", "", nil, matched, err) }()
+
 	var expected *ExpectedBegin
 	var ok bool
 	var fulfilled int
 	for _, next := range c.expected {
 		next.Lock()
-		if next.fulfilled() {
+		if next.exhausted() {
 			next.Unlock()
 			fulfilled++
 			continue
@@ -185,8 +229,9 @@ func (c *sqlmock) begin() (*ExpectedBegin, error) {
 			break
 		}
 
+		optional := next.fulfilled()
 		next.Unlock()
-		if c.ordered {
+		if c.ordered && !optional {
 			return nil, fmt.Errorf("call to database transaction Begin, was not expected, next expectation is : %s", next)
 		}
 	}
@@ -197,7 +242,8 @@ func (c *sqlmock) begin() (*ExpectedBegin, error) {
 		}
 		return nil, fmt.Errorf(msg)
 	}
-	expected.triggered = true
+	expected.trigger()
+	matched = expected
 	expected.Unlock()
 
 	return expected, expected.err
@@ -217,7 +263,7 @@ func (c *sqlmock) Exec(query string, args []driver.Value) (driver.Result, error)
 			Value:   v,
 		}
 	}
-	ex, err := c.exec(query, namedArgs)
+	ex, res, err := c.exec(query, namedArgs)
 	if ex != nil {
 		time.Sleep(ex.delay)
 	}
@@ -225,27 +271,35 @@ func (c *sqlmock) Exec(query string, args []driver.Value) (driver.Result, error)
 		return nil, err
 	}
 
-	return ex.result, nil
+	return res, nil
 }
 
-func (c *sqlmock) exec(query string, args []namedValue) (*ExpectedExec, error) {
+func (c *sqlmock) exec(query string, args []namedValue) (_ *ExpectedExec, _ driver.Result, err error) {
+	var matched expectation
+	defer func() { c.called("Exec", query, args, matched, err) }()
+
 	var expected *ExpectedExec
 	var fulfilled int
-	var ok bool
 	for _, next := range c.expected {
 		next.Lock()
-		if next.fulfilled() {
+		if next.exhausted() {
 			next.Unlock()
 			fulfilled++
 			continue
 		}
 
 		if c.ordered {
-			if expected, ok = next.(*ExpectedExec); ok {
+			// an optional expectation is skipped unless it matches
+			if exec, ok := next.(*ExpectedExec); ok && (!next.fulfilled() || exec.matches(c.queryMatcher, query, args)) {
+				expected = exec
 				break
 			}
+			optional := next.fulfilled()
 			next.Unlock()
-			return nil, fmt.Errorf("call to ExecQuery '%s' with args %+v, was not expected, next expectation is : %s", query, args, next)
+			if optional {
+				continue
+			}
+			return nil, nil, fmt.Errorf("call to ExecQuery '%s' with args %+v, was not expected, next expectation is : %s", query, args, next)
 		}
 		if exec, ok := next.(*ExpectedExec); ok {
 			if err := c.queryMatcher.Match(exec.expectSQL, query); err != nil {
@@ -253,7 +307,7 @@ func (c *sqlmock) exec(query string, args []namedValue) (*ExpectedExec, error) {
 				continue
 			}
 
-			if err := exec.attemptArgMatch(args); err != nil {
+			if err := exec.attemptArgMatch(args); err == nil {
 				expected = exec
 				break
 			}
@@ -265,19 +319,29 @@ func (c *sqlmock) exec(query string, args []namedValue) (*ExpectedExec, error) {
 		if fulfilled == len(c.expected) {
 			msg = "all expectations were already fulfilled, " + msg
 		}
-		return nil, fmt.Errorf(msg, query, args)
+		return nil, nil, fmt.Errorf(msg, query, args)
 	}
 	defer expected.Unlock()
 
 	if err := c.queryMatcher.Match(expected.expectSQL, query); err != nil {
-		return nil, fmt.Errorf("ExecQuery: %v", err)
+		return nil, nil, fmt.Errorf("ExecQuery: %v", err)
 	}
 
 	if err := expected.argsMatches(args); err != nil {
-		return nil, fmt.Errorf("ExecQuery '%s' with args %+v, must return a database/sql/driver.Result, but it was not set for expectation %T as %+v", query, args, expected, expected)
+		return nil, nil, fmt.Errorf("ExecQuery '%s', arguments do not match: %s", query, err)
 	}
 
-	return expected, nil
+	expected.trigger()
+	matched = expected
+	if expected.err != nil {
+		return expected, nil, expected.err
+	}
+
+	if expected.result == nil && expected.resultFn == nil {
+		return nil, nil, fmt.Errorf("ExecQuery '%s' with args %+v, must return a database/sql/driver.Result, but it was not set for expectation %T as %+v", query, args, expected, expected)
+	}
+	res, err := expected.nextResult(args)
+	return expected, res, err
 }
 
 func (c *sqlmock) ExpectExec(expectedSQL string) *ExpectedExec {
@@ -299,14 +363,17 @@ func (c *sqlmock) Prepare(query string) (driver.Stmt, error) {
 	return &statement{c, ex, query}, nil
 }
 
-func (c *sqlmock) prepare(query string) (*ExpectedPrepare, error) {
+func (c *sqlmock) prepare(query string) (_ *ExpectedPrepare, err error) {
+	var matched expectation
+	defer func() { c.called("Prepare", query, nil, matched, err) }()
+
 	var expected *ExpectedPrepare
 	var fulfilled int
 	var ok bool
 
 	for _, next := range c.expected {
 		next.Lock()
-		if next.fulfilled() {
+		if next.exhausted() {
 			next.Unlock()
 			fulfilled++
 			continue
@@ -316,7 +383,11 @@ func (c *sqlmock) prepare(query string) (*ExpectedPrepare, error) {
 			if expected, ok = next.(*ExpectedPrepare); ok {
 				break
 			}
+			optional := next.fulfilled()
 			next.Unlock()
+			if optional {
+				continue
+			}
 			return nil, fmt.Errorf("call to Prepare statement with query '%s', was not expected, next expectation is : %s", query, next)
 		}
 
@@ -340,7 +411,8 @@ func (c *sqlmock) prepare(query string) (*ExpectedPrepare, error) {
 	if err := c.queryMatcher.Match(expected.expectSQL, query); err != nil {
 		return nil, fmt.Errorf("Prepare: %v", err)
 	}
-	expected.triggered = true
+	expected.trigger()
+	matched = expected
 	return expected, expected.err
 }
 
@@ -365,34 +437,42 @@ func (c *sqlmock) Query(query string, args []driver.Value) (driver.Rows, error)
 		}
 	}
 
-	ex, err := c.query(query, namedArgs)
+	ex, rows, err := c.query(query, namedArgs)
 	if ex != nil {
 		time.Sleep(ex.delay)
 	}
 	if err != nil {
 		return nil, err
 	}
-	return ex.rows, nil
+	return rows, nil
 }
 
-func (c *sqlmock) query(query string, args []namedValue) (*ExpectedQuery, error) {
+func (c *sqlmock) query(query string, args []namedValue) (_ *ExpectedQuery, _ driver.Rows, err error) {
+	var matched expectation
+	defer func() { c.called("Query", query, args, matched, err) }()
+
 	var expected *ExpectedQuery
 	var fulfilled int
-	var ok bool
 	for _, next := range c.expected {
 		next.Lock()
-		if next.fulfilled() {
+		if next.exhausted() {
 			next.Unlock()
 			fulfilled++
 			continue
 		}
 
 		if c.ordered {
-			if expected, ok = next.(*ExpectedQuery); ok {
+			// an optional expectation is skipped unless it matches
+			if qr, ok := next.(*ExpectedQuery); ok && (!next.fulfilled() || qr.matches(c.queryMatcher, query, args)) {
+				expected = qr
 				break
 			}
+			optional := next.fulfilled()
 			next.Unlock()
-			return nil, fmt.Errorf("call to Query '%s' with args %+v, was expeted, next expectation is: %s", query, args, next)
+			if optional {
+				continue
+			}
+			return nil, nil, fmt.Errorf("call to Query '%s' with args %+v, was expeted, next expectation is: %s", query, args, next)
 		}
 		if qr, ok := next.(*ExpectedQuery); ok {
 			if err := c.queryMatcher.Match(qr.expectSQL, query); err != nil {
@@ -411,28 +491,30 @@ func (c *sqlmock) query(query string, args []namedValue) (*ExpectedQuery, error)
 		if fulfilled == len(c.expected) {
 			msg = "all expectations were already fulfilled, " + msg
 		}
-		return nil, fmt.Errorf(msg, query, args)
+		return nil, nil, fmt.Errorf(msg, query, args)
 	}
 
 	defer expected.Unlock()
 
 	if err := c.queryMatcher.Match(expected.expectSQL, query); err != nil {
-		return nil, fmt.Errorf("Query: %v", err)
+		return nil, nil, fmt.Errorf("Query: %v", err)
 	}
 
 	if err := expected.argsMatches(args); err != nil {
-		return nil, fmt.Errorf("Query '%s', arguments do not match: %s", query, err)
+		return nil, nil, fmt.Errorf("Query '%s', arguments do not match: %s", query, err)
 	}
 
-	expected.triggered = true
+	expected.trigger()
+	matched = expected
 	if expected.err != nil {
-		return expected, expected.err
+		return expected, nil, expected.err
 	}
 
-	if expected.rows == nil {
-		return nil, fmt.Errorf("Query '%s' with args %+v, must return a database/sql/driver.Rows, but it was not set for expectation %T as %+v", query, args, expected, expected)
+	if expected.rows == nil && expected.rowsFn == nil {
+		return nil, nil, fmt.Errorf("Query '%s' with args %+v, must return a database/sql/driver.Rows, but it was not set for expectation %T as %+v", query, args, expected, expected)
 	}
-	return expected, nil
+	rows, err := expected.nextRows(args)
+	return expected, rows, err
 }
 
 func (c *sqlmock) ExpectQuery(expectedSQL string) *ExpectedQuery {
@@ -455,13 +537,64 @@ func (c *sqlmock) ExpectRollback() *ExpectedRollback {
 	return e
 }
 
-func (c *sqlmock) Commit() error {
+func (c *sqlmock) ExpectPing() *ExpectedPing {
+	e := &ExpectedPing{}
+	if !c.monitorPings {
+		log.Println("ExpectPing will have no effect as monitoring pings is disabled. Use MonitorPingsOption to enable.")
+		return e
+	}
+	c.expected = append(c.expected, e)
+	return e
+}
+
+func (c *sqlmock) ping() (_ *ExpectedPing, err error) {
+	var matched expectation
+	defer func() { c.called("Ping", "", nil, matched, err) }()
+
+	var expected *ExpectedPing
+	var fulfilled int
+	var ok bool
+	for _, next := range c.expected {
+		next.Lock()
+		if next.exhausted() {
+			next.Unlock()
+			fulfilled++
+			continue
+		}
+
+		if expected, ok = next.(*ExpectedPing); ok {
+			break
+		}
+
+		optional := next.fulfilled()
+		next.Unlock()
+		if c.ordered && !optional {
+			return nil, fmt.Errorf("call to database Ping, was not expected, next expectation is: %s", next)
+		}
+	}
+	if expected == nil {
+		msg := "call to database Ping was not expected"
+		if fulfilled == len(c.expected) {
+			msg = "all expectations were already fulfilled, " + msg
+		}
+		return nil, fmt.Errorf(msg)
+	}
+	expected.trigger()
+	matched = expected
+	expected.Unlock()
+	return expected, expected.err
+}
+
+func (c *sqlmock) Commit() (err error) {
+	var matched expectation
+	defer func() { c.called("Commit", "", nil, matched, err) }()
+
 	var expected *ExpectedCommit
 	var fulfilled int
 	var ok bool
 	for _, next := range c.expected {
 		next.Lock()
-		if next.fulfilled() {
+		if next.exhausted() {
 			next.Unlock()
 			fulfilled++
 			continue
@@ -471,8 +604,9 @@ func (c *sqlmock) Commit() error {
 			break
 		}
 
+		optional := next.fulfilled()
 		next.Unlock()
-		if c.ordered {
+		if c.ordered && !optional {
 			return fmt.Errorf("call to Commit transaction, was not expected, next expectation is : %s", next)
 		}
 	}
@@ -484,18 +618,22 @@ func (c *sqlmock) Commit() error {
 		return fmt.Errorf(msg)
 	}
 
-	expected.triggered = true
+	expected.trigger()
+	matched = expected
 	expected.Unlock()
 	return expected.err
 }
 
-func (c *sqlmock) Rollback() error {
+func (c *sqlmock) Rollback() (err error) {
+	var matched expectation
+	defer func() { c.called("Rollback", "", nil, matched, err) }()
+
 	var expected *ExpectedRollback
 	var fulfilled int
 	var ok bool
 	for _, next := range c.expected {
 		next.Lock()
-		if next.fulfilled() {
+		if next.exhausted() {
 			next.Unlock()
 			fulfilled++
 			continue
@@ -505,8 +643,9 @@ func (c *sqlmock) Rollback() error {
 			break
 		}
 
+		optional := next.fulfilled()
 		next.Unlock()
-		if c.ordered {
+		if c.ordered && !optional {
 			return fmt.Errorf("call to Rollback transaction, was not expected, next expectation is: %s", next)
 		}
 	}
@@ -517,7 +656,8 @@ func (c *sqlmock) Rollback() error {
 		}
 		return fmt.Errorf(msg)
 	}
-	expected.triggered = true
+	expected.trigger()
+	matched = expected
 	expected.Unlock()
 	return expected.err
 }
@@ -527,3 +667,9 @@ func (c *sqlmock) NewRows(columns []string) *Rows {
 	r.converter = c.converter
 	return r
 }
+
+func (c *sqlmock) NewRowsWithColumnDefinition(columns ...*Column) *Rows {
+	r := NewRowsWithColumnDefinition(columns...)
+	r.converter = c.converter
+	return r
+}
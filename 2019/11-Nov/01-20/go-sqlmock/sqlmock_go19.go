@@ -0,0 +1,23 @@
+//go:build go1.9
+// +build go1.9
+
+package sqlmock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// CheckNamedValue meets https://golang.org/pkg/database/sql/driver/#NamedValueChecker
+// Output parameters are passed through as they are, every other value is
+// converted by the mock ValueConverter, so custom types are accepted as long
+// as the converter set with ValueConverterOption supports them.
+func (c *sqlmock) CheckNamedValue(nv *driver.NamedValue) (err error) {
+	switch nv.Value.(type) {
+	case sql.Out:
+		return nil
+	default:
+		nv.Value, err = c.converter.ConvertValue(nv.Value)
+		return err
+	}
+}
@@ -0,0 +1,179 @@
+//go:build go1.9
+// +build go1.9
+
+package sqlmock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+type money struct {
+	cents int64
+}
+
+type moneyConverter struct{}
+
+func (moneyConverter) ConvertValue(v interface{}) (driver.Value, error) {
+	if m, ok := v.(money); ok {
+		return m.cents, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+func TestNamedArguments(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	// named arguments are matched by name, regardless of their position
+	mock.ExpectExec("UPDATE users").
+		WithArgs(sql.Named("name", "john"), sql.Named("id", 5)).
+		WillReturnResult(NewResult(0, 1))
+
+	_, err = db.Exec("UPDATE users SET name = :name WHERE id = :id", sql.Named("id", 5), sql.Named("name", "john"))
+	if err != nil {
+		t.Errorf("error was not expected, but got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestNamedArgumentsMismatch(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		expected []driver.Value
+		actual   []interface{}
+		err      string
+	}{
+		{
+			[]driver.Value{sql.Named("id", 5)},
+			[]interface{}{sql.Named("id", 6)},
+			`named argument "id": expected [int64 - 5] does not match actual [int64 - 6]`,
+		},
+		{
+			[]driver.Value{sql.Named("id", 5)},
+			[]interface{}{sql.Named("user", 5)},
+			`named argument "id": no actual argument with this name was given`,
+		},
+		{
+			[]driver.Value{sql.Named("id", AnyArg())},
+			[]interface{}{5},
+			`named argument "id": no actual argument with this name was given`,
+		},
+		{
+			[]driver.Value{5, "john"},
+			[]interface{}{5, "jane"},
+			`argument 1: expected [string - john] does not match actual [string - jane]`,
+		},
+	}
+
+	for i, c := range cases {
+		db, mock, err := New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		mock.ExpectQuery("SELECT").WithArgs(c.expected...).WillReturnRows(NewRows([]string{"id"}))
+
+		_, err = db.Query("SELECT", c.actual...)
+		if err == nil {
+			t.Errorf("expected an error at %d case, but got none", i)
+		} else if !strings.Contains(err.Error(), c.err) {
+			t.Errorf(`expected error to contain "%s" at %d case, but got: %s`, c.err, i, err)
+		}
+		db.Close()
+	}
+}
+
+func TestNamedArgumentWithMatcher(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(sql.Named("id", AnyArg())).
+		WillReturnRows(NewRows([]string{"id"}).AddRow(5))
+
+	rows, err := db.Query("SELECT id FROM users WHERE id = :id", sql.Named("id", 5))
+	if err != nil {
+		t.Errorf("error was not expected, but got: %v", err)
+	}
+	rows.Close()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOutputArgument(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	var total int64
+	mock.ExpectExec("CALL count_users").
+		WithArgs(sql.Named("total", sql.Out{Dest: &total})).
+		WillReturnResult(NewResult(0, 0))
+
+	_, err = db.Exec("CALL count_users(:total)", sql.Named("total", sql.Out{Dest: &total}))
+	if err != nil {
+		t.Errorf("error was not expected, but got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCustomTypeArgument(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(ValueConverterOption(moneyConverter{}))
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO payments").
+		WithArgs(money{cents: 1050}).
+		WillReturnResult(NewResult(1, 1))
+
+	_, err = db.Exec("INSERT INTO payments(amount) VALUES (?)", money{cents: 1050})
+	if err != nil {
+		t.Errorf("error was not expected, but got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCustomTypeArgumentNotConvertible(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO payments").WillReturnResult(NewResult(1, 1))
+
+	_, err = db.Exec("INSERT INTO payments(amount) VALUES (?)", money{cents: 1050})
+	if err == nil {
+		t.Error("expected an error for a value the converter does not support")
+	}
+}
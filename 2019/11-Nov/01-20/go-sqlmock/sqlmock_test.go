@@ -53,11 +53,11 @@ func TestIssue14EscapeSQL(t *testing.T) {
 		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 	defer db.Close()
-	mock.ExpectExec("INSERT INTO mytable\\(a,b)\\").
+	mock.ExpectExec("INSERT INTO mytable\\(a, b\\)").
 		WithArgs("A", "B").
 		WillReturnResult(NewResult(1, 1))
 
-	_, err = db.Exec("INSERT INT mytable(a,b) VALUES (?, ?)", "A", "B")
+	_, err = db.Exec("INSERT INTO mytable(a, b) VALUES (?, ?)", "A", "B")
 	if err != nil {
 		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
 	}
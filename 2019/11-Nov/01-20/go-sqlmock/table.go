@@ -0,0 +1,174 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sync"
+)
+
+// Table is a small in-memory table, which can back the rows and
+// results of CRUD-style expectations. Its first column is an int64
+// id, assigned in sequence on insert. The methods taking query
+// arguments can be passed to ExpectedQuery.WillReturnRowsFunc and
+// ExpectedExec.WillReturnResultFunc:
+//
+//	people := sqlmock.NewTable("id", "fullname", "phone")
+//	people.Insert("John", "0123456789")
+//
+//	mock.ExpectQuery("SELECT (.+) FROM person WHERE id = ?").
+//		WithArgs(sqlmock.AnyArg()).
+//		WillReturnRowsFunc(people.SelectByID).
+//		AnyTimes()
+//	mock.ExpectExec("INSERT INTO person").
+//		WillReturnResultFunc(people.InsertRow)
+//
+// A Table is safe for concurrent use.
+type Table struct {
+	mu      sync.Mutex
+	columns []string
+	rows    [][]driver.Value
+	lastID  int64
+}
+
+// NewTable creates an empty Table with the given columns, the first
+// of which is the id
+func NewTable(columns ...string) *Table {
+	if len(columns) == 0 {
+		panic("sqlmock: a table needs at least an id column")
+	}
+	return &Table{columns: columns}
+}
+
+// Columns returns the column names of the table
+func (t *Table) Columns() []string {
+	return t.columns
+}
+
+// Insert adds a row with the values of the columns after the id, and
+// returns the id assigned to it
+func (t *Table) Insert(values ...driver.Value) int64 {
+	id, err := t.insert(values)
+	if err != nil {
+		panic("sqlmock: " + err.Error())
+	}
+	return id
+}
+
+// Len returns the number of rows in the table
+func (t *Table) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.rows)
+}
+
+// Rows returns all the rows of the table, ordered by id
+func (t *Table) Rows() *Rows {
+	return t.Where(func([]driver.Value) bool { return true })
+}
+
+// Where returns the rows of the table for which match returns true
+func (t *Table) Where(match func(row []driver.Value) bool) *Rows {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rows := NewRows(t.columns)
+	for _, row := range t.rows {
+		if match(row) {
+			rows.AddRow(row...)
+		}
+	}
+	return rows
+}
+
+// SelectByID returns the row with the id given as the single query
+// argument, or no rows if there is no such row
+func (t *Table) SelectByID(args []driver.Value) (*Rows, error) {
+	id, err := t.idArg(args, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	return t.Where(func(row []driver.Value) bool { return row[0] == id }), nil
+}
+
+// SelectAll returns all the rows, the query arguments are ignored
+func (t *Table) SelectAll(args []driver.Value) (*Rows, error) {
+	return t.Rows(), nil
+}
+
+// InsertRow inserts a row with the values of the columns after the id
+// given as query arguments, as in
+// INSERT INTO person(fullname, phone) VALUES (?, ?). The result has the
+// assigned id as last insert id.
+func (t *Table) InsertRow(args []driver.Value) (driver.Result, error) {
+	id, err := t.insert(args)
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(id, 1), nil
+}
+
+// UpdateByID updates the row with the id given as last query argument
+// with the values of the columns after the id given before it, as in
+// UPDATE person SET fullname = ?, phone = ? WHERE id = ?
+func (t *Table) UpdateByID(args []driver.Value) (driver.Result, error) {
+	id, err := t.idArg(args, len(args)-1, len(t.columns))
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, row := range t.rows {
+		if row[0] == id {
+			copy(row[1:], args[:len(args)-1])
+			return NewResult(0, 1), nil
+		}
+	}
+	return NewResult(0, 0), nil
+}
+
+// DeleteByID deletes the row with the id given as the single query
+// argument, as in DELETE FROM person WHERE id = ?
+func (t *Table) DeleteByID(args []driver.Value) (driver.Result, error) {
+	id, err := t.idArg(args, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, row := range t.rows {
+		if row[0] == id {
+			t.rows = append(t.rows[:i], t.rows[i+1:]...)
+			return NewResult(0, 1), nil
+		}
+	}
+	return NewResult(0, 0), nil
+}
+
+func (t *Table) insert(values []driver.Value) (int64, error) {
+	if len(values) != len(t.columns)-1 {
+		return 0, fmt.Errorf("table insert expects %d column values, but got %d", len(t.columns)-1, len(values))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastID++
+	row := make([]driver.Value, 0, len(t.columns))
+	row = append(row, t.lastID)
+	t.rows = append(t.rows, append(row, values...))
+	return t.lastID, nil
+}
+
+// idArg returns the id at position i of the query arguments, which
+// are expected to be n
+func (t *Table) idArg(args []driver.Value, i, n int) (int64, error) {
+	if len(args) != n {
+		return 0, fmt.Errorf("table expects %d query arguments, but got %d", n, len(args))
+	}
+	id, ok := args[i].(int64)
+	if !ok {
+		return 0, fmt.Errorf("table expects an int64 id as argument %d, but got [%T - %+v]", i, args[i], args[i])
+	}
+	return id, nil
+}
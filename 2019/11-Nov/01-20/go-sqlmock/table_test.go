@@ -0,0 +1,143 @@
+package sqlmock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+type person struct {
+	id       int64
+	fullName string
+	phone    string
+}
+
+const (
+	sqlLoadByID = "SELECT id, fullname, phone FROM person WHERE id = ? LIMIT 1"
+	sqlLoadAll  = "SELECT id, fullname, phone FROM person"
+	sqlInsert   = "INSERT INTO person (fullname, phone) VALUES (?, ?)"
+	sqlUpdate   = "UPDATE person SET fullname = ?, phone = ? WHERE id = ?"
+	sqlDelete   = "DELETE FROM person WHERE id = ?"
+)
+
+func loadPerson(db *sql.DB, id int64) (*person, error) {
+	p := &person{}
+	err := db.QueryRow(sqlLoadByID, id).Scan(&p.id, &p.fullName, &p.phone)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func TestTableCRUD(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(QueryMatcherOption(QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	people := NewTable("id", "fullname", "phone")
+	people.Insert("John", "0123456789")
+
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(sqlLoadByID).WithArgs(AnyArg()).WillReturnRowsFunc(people.SelectByID).AnyTimes()
+	mock.ExpectQuery(sqlLoadAll).WillReturnRowsFunc(people.SelectAll).AnyTimes()
+	mock.ExpectExec(sqlInsert).WithArgs(AnyArg(), AnyArg()).WillReturnResultFunc(people.InsertRow)
+	mock.ExpectExec(sqlUpdate).WithArgs(AnyArg(), AnyArg(), AnyArg()).WillReturnResultFunc(people.UpdateByID)
+	mock.ExpectExec(sqlDelete).WithArgs(AnyArg()).WillReturnResultFunc(people.DeleteByID).AnyTimes()
+
+	p, err := loadPerson(db, 1)
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if *p != (person{1, "John", "0123456789"}) {
+		t.Errorf("unexpected person: %+v", p)
+	}
+
+	res, err := db.Exec(sqlInsert, "Paul", "0987654321")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if id, _ := res.LastInsertId(); id != 2 {
+		t.Errorf("expected the inserted id to be 2, but got: %d", id)
+	}
+
+	if _, err := db.Exec(sqlUpdate, "Paul", "555", 2); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if p, err = loadPerson(db, 2); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if *p != (person{2, "Paul", "555"}) {
+		t.Errorf("unexpected person after update: %+v", p)
+	}
+
+	res, err = db.Exec(sqlDelete, 1)
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if affected, _ := res.RowsAffected(); affected != 1 {
+		t.Errorf("expected 1 deleted row, but got: %d", affected)
+	}
+	res, err = db.Exec(sqlDelete, 1)
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if affected, _ := res.RowsAffected(); affected != 0 {
+		t.Errorf("expected no deleted row, but got: %d", affected)
+	}
+	if _, err := loadPerson(db, 1); err != sql.ErrNoRows {
+		t.Errorf("expected sql.ErrNoRows for a deleted person, but got: %v", err)
+	}
+
+	rows, err := db.Query(sqlLoadAll)
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var p person
+		if err := rows.Scan(&p.id, &p.fullName, &p.phone); err != nil {
+			t.Errorf("error was not expected, but got: %s", err)
+		}
+		ids = append(ids, p.id)
+	}
+	rows.Close()
+	if len(ids) != 1 || ids[0] != 2 || people.Len() != 1 {
+		t.Errorf("expected only person 2 to be left, but got: %v", ids)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTableArgumentErrors(t *testing.T) {
+	t.Parallel()
+	people := NewTable("id", "fullname")
+
+	cases := []struct {
+		name string
+		fn   func() error
+		msg  string
+	}{
+		{"select by string id", func() error {
+			_, err := people.SelectByID([]driver.Value{"1"})
+			return err
+		}, "table expects an int64 id as argument 0, but got [string - 1]"},
+		{"insert too many values", func() error {
+			_, err := people.InsertRow([]driver.Value{"John", "0123"})
+			return err
+		}, "table insert expects 1 column values, but got 2"},
+		{"update without id", func() error {
+			_, err := people.UpdateByID([]driver.Value{"John"})
+			return err
+		}, "table expects 2 query arguments, but got 1"},
+	}
+	for _, c := range cases {
+		if err := c.fn(); err == nil || err.Error() != c.msg {
+			t.Errorf("%s: expected error %q, but got: %v", c.name, c.msg, err)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package altsrc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gy-kim/golang-daily-practice/2019/12-Dec/16-31/urfave/cli"
+)
+
+var configFiles = map[string]string{
+	"yaml": `
+name: from-config
+port: 8080
+timeout: 5s
+tags: [a, b]
+count: 3
+big: 9000000000
+since: 2019-12-01T00:00:00Z
+db:
+  host: db.internal
+`,
+	"toml": `
+name = "from-config"
+port = 8080
+timeout = "5s"
+tags = ["a", "b"]
+count = 3
+big = 9000000000
+since = 2019-12-01T00:00:00Z
+
+[db]
+host = "db.internal"
+`,
+	"json": `{
+  "name": "from-config",
+  "port": 8080,
+  "timeout": "5s",
+  "tags": ["a", "b"],
+  "count": 3,
+  "big": 9000000000,
+  "since": "2019-12-01T00:00:00Z",
+  "db": {"host": "db.internal"}
+}`,
+}
+
+type config struct {
+	name    string
+	port    int
+	timeout time.Duration
+	tags    []string
+	count   uint
+	big     uint64
+	since   *time.Time
+	host    string
+}
+
+func runWithConfig(t *testing.T, newSource func(string) func(*cli.Context) (InputSourceContext, error), args ...string) config {
+	var got config
+
+	flags := []cli.Flag{
+		&cli.StringFlag{Name: "config"},
+		NewStringFlag(&cli.StringFlag{Name: "name", Value: "default", EnvVars: []string{"ALTSRC_TEST_NAME"}}),
+		NewIntFlag(&cli.IntFlag{Name: "port", Value: 80}),
+		NewDurationFlag(&cli.DurationFlag{Name: "timeout"}),
+		NewStringSliceFlag(&cli.StringSliceFlag{Name: "tags"}),
+		NewUintFlag(&cli.UintFlag{Name: "count"}),
+		NewUint64Flag(&cli.Uint64Flag{Name: "big"}),
+		NewTimestampFlag(&cli.TimestampFlag{Name: "since", Layout: time.RFC3339}),
+		NewStringFlag(&cli.StringFlag{Name: "db.host"}),
+	}
+
+	app := &cli.App{
+		Writer: &bytes.Buffer{},
+		Flags:  flags,
+		Before: InitInputSourceWithContext(flags, newSource("config")),
+		Action: func(c *cli.Context) error {
+			got = config{
+				name:    c.String("name"),
+				port:    c.Int("port"),
+				timeout: c.Duration("timeout"),
+				tags:    c.StringSlice("tags"),
+				count:   c.Uint("count"),
+				big:     c.Uint64("big"),
+				since:   c.Timestamp("since"),
+				host:    c.String("db.host"),
+			}
+			return nil
+		},
+	}
+
+	if err := app.Run(append([]string{"app"}, args...)); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	return got
+}
+
+func TestInputSourcesApplyConfigValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "altsrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for format, newSource := range map[string]func(string) func(*cli.Context) (InputSourceContext, error){
+		"yaml": NewYamlSourceFromFlagFunc,
+		"toml": NewTomlSourceFromFlagFunc,
+		"json": NewJSONSourceFromFlagFunc,
+	} {
+		file := filepath.Join(dir, "config."+format)
+		if err := ioutil.WriteFile(file, []byte(configFiles[format]), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		got := runWithConfig(t, newSource, "--config", file)
+		if got.name != "from-config" || got.port != 8080 || got.timeout != 5*time.Second || got.host != "db.internal" {
+			t.Errorf("%s: expected values from config file, but got: %+v", format, got)
+		}
+		if len(got.tags) != 2 || got.tags[0] != "a" || got.tags[1] != "b" {
+			t.Errorf("%s: expected tags [a b], but got: %v", format, got.tags)
+		}
+		if got.count != 3 || got.big != 9000000000 {
+			t.Errorf("%s: expected count 3 and big 9000000000, but got: %d and %d", format, got.count, got.big)
+		}
+		if since := time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC); got.since == nil || !got.since.Equal(since) {
+			t.Errorf("%s: expected since %s, but got: %v", format, since, got.since)
+		}
+	}
+}
+
+func TestInputSourcePrecedence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "altsrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(file, []byte(configFiles["yaml"]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := runWithConfig(t, NewYamlSourceFromFlagFunc, "--config", file, "--port", "9090")
+	if got.port != 9090 {
+		t.Errorf("expected command line to win over config file, but got port: %d", got.port)
+	}
+
+	os.Setenv("ALTSRC_TEST_NAME", "from-env")
+	defer os.Unsetenv("ALTSRC_TEST_NAME")
+
+	got = runWithConfig(t, NewYamlSourceFromFlagFunc, "--config", file)
+	if got.name != "from-env" {
+		t.Errorf("expected environment to win over config file, but got name: %q", got.name)
+	}
+
+	got = runWithConfig(t, NewYamlSourceFromFlagFunc)
+	if got.port != 80 || got.timeout != 0 {
+		t.Errorf("expected defaults without config file, but got: %+v", got)
+	}
+}
+
+func TestMapInputSourceTypeMismatch(t *testing.T) {
+	isc := NewMapInputSource("test", map[interface{}]interface{}{"port": "eighty"})
+
+	if _, err := isc.Int("port"); err == nil {
+		t.Error("expected type mismatch error, but got none")
+	}
+	if v, err := isc.Int("missing"); v != 0 || err != nil {
+		t.Errorf("expected zero value and no error for missing key, but got: %d, %v", v, err)
+	}
+}
+
+func TestMapInputSourceTimestamp(t *testing.T) {
+	since := time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC)
+	isc := NewMapInputSource("test", map[interface{}]interface{}{
+		"native": since,
+		"string": "2019-12-01",
+		"bad":    "yesterday",
+		"number": 20191201,
+	})
+
+	if v, err := isc.Timestamp("native", time.RFC3339); err != nil || !v.Equal(since) {
+		t.Errorf("expected native timestamp %s, but got: %s, %v", since, v, err)
+	}
+	if v, err := isc.Timestamp("string", "2006-01-02"); err != nil || !v.Equal(since) {
+		t.Errorf("expected timestamp %s parsed with layout, but got: %s, %v", since, v, err)
+	}
+	if _, err := isc.Timestamp("bad", "2006-01-02"); err == nil {
+		t.Error("expected parse error, but got none")
+	}
+	if _, err := isc.Timestamp("number", "2006-01-02"); err == nil {
+		t.Error("expected type mismatch error, but got none")
+	}
+	if v, err := isc.Timestamp("missing", "2006-01-02"); !v.IsZero() || err != nil {
+		t.Errorf("expected zero value and no error for missing key, but got: %s, %v", v, err)
+	}
+}
+
+func TestInputSourceWithCommandFromStruct(t *testing.T) {
+	dir, err := ioutil.TempDir("", "altsrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(file, []byte("name: from-config\ntags: [a, b]\nsince: \"2019-12-01\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts struct {
+		Name  string    `cli:"name"`
+		Tags  []string  `cli:"tags"`
+		Since time.Time `cli:"since" layout:"2006-01-02"`
+	}
+	cmd, err := cli.CommandFromStruct(&opts)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	for i, f := range cmd.Flags {
+		switch f := f.(type) {
+		case *cli.StringFlag:
+			cmd.Flags[i] = NewStringFlag(f)
+		case *cli.StringSliceFlag:
+			cmd.Flags[i] = NewStringSliceFlag(f)
+		case *cli.TimestampFlag:
+			cmd.Flags[i] = NewTimestampFlag(f)
+		}
+	}
+	cmd.Flags = append(cmd.Flags, &cli.StringFlag{Name: "config"})
+	cmd.Name = "serve"
+	cmd.Before = InitInputSourceWithContext(cmd.Flags, NewYamlSourceFromFlagFunc("config"))
+
+	var seenName string
+	var seenTags []string
+	var seenSince time.Time
+	cmd.Action = func(c *cli.Context) error {
+		seenName, seenTags, seenSince = opts.Name, opts.Tags, opts.Since
+		return nil
+	}
+
+	app := &cli.App{Writer: &bytes.Buffer{}, Commands: []*cli.Command{cmd}}
+	if err := app.Run([]string{"app", "serve", "--config", file}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+
+	if seenName != "from-config" {
+		t.Errorf("expected name from config file, but got: %q", seenName)
+	}
+	if len(seenTags) != 2 || seenTags[0] != "a" || seenTags[1] != "b" {
+		t.Errorf("expected tags [a b] from config file, but got: %v", seenTags)
+	}
+	if !seenSince.Equal(time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected since from config file, but got: %v", seenSince)
+	}
+}
@@ -0,0 +1,316 @@
+package altsrc
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"syscall"
+
+	"github.com/gy-kim/golang-daily-practice/2019/12-Dec/16-31/urfave/cli"
+)
+
+// FlagInputSourceExtension is an extension interface of cli.Flag that
+// allows a value to be set on the existing parsed flags.
+type FlagInputSourceExtension interface {
+	cli.Flag
+	ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error
+}
+
+// ApplyInputSourceValues iterates over all provided flags and
+// executes ApplyInputSourceValue on flags implementing the
+// FlagInputSourceExtension interface to initialize these flags
+// to an alternate input source.
+func ApplyInputSourceValues(context *cli.Context, inputSourceContext InputSourceContext, flags []cli.Flag) error {
+	for _, f := range flags {
+		inputSourceExtendedFlag, isType := f.(FlagInputSourceExtension)
+		if isType {
+			err := inputSourceExtendedFlag.ApplyInputSourceValue(context, inputSourceContext)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// InitInputSource is used to to setup an InputSourceContext on a cli.Command Before method. It will create a new
+// input source based on the func provided. If there is no error it will then apply the new input source to any flags
+// that are supported by the input source
+func InitInputSource(flags []cli.Flag, createInputSource func() (InputSourceContext, error)) cli.BeforeFunc {
+	return func(context *cli.Context) error {
+		inputSource, err := createInputSource()
+		if err != nil {
+			return fmt.Errorf("Unable to create input source: inner error: \n'%v'", err.Error())
+		}
+
+		return ApplyInputSourceValues(context, inputSource, flags)
+	}
+}
+
+// InitInputSourceWithContext is used to to setup an InputSourceContext on a cli.Command Before method. It will create a new
+// input source based on the func provided with potentially using existing cli.Context values to initialize itself. If there is
+// no error it will then apply the new input source to any flags that are supported by the input source.
+//
+// Values from the input source are only applied to flags which were not set on the command line or through
+// one of their environment variables, so the precedence is command line, environment, input source and
+// finally the flag default.
+func InitInputSourceWithContext(flags []cli.Flag, createInputSource func(context *cli.Context) (InputSourceContext, error)) cli.BeforeFunc {
+	return func(context *cli.Context) error {
+		inputSource, err := createInputSource(context)
+		if err != nil {
+			return fmt.Errorf("Unable to create input source with context: inner error: \n'%v'", err.Error())
+		}
+
+		return ApplyInputSourceValues(context, inputSource, flags)
+	}
+}
+
+// ApplyInputSourceValue applies a generic value to the flagSet if required
+func (f *GenericFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.String(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), value)
+}
+
+// ApplyInputSourceValue applies a StringSlice value to the flagSet if required
+func (f *StringSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.StringSlice(f.Name)
+	if err != nil {
+		return err
+	}
+	sliceValue := cli.NewStringSlice(value...)
+	for _, name := range f.Names() {
+		if underlyingFlag := f.set.Lookup(name); underlyingFlag != nil {
+			underlyingFlag.Value = sliceValue
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a IntSlice value to the flagSet if required
+func (f *IntSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.IntSlice(f.Name)
+	if err != nil {
+		return err
+	}
+	sliceValue := cli.NewIntSlice(value...)
+	for _, name := range f.Names() {
+		if underlyingFlag := f.set.Lookup(name); underlyingFlag != nil {
+			underlyingFlag.Value = sliceValue
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a Int64Slice value to the flagSet if required
+func (f *Int64SliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Int64Slice(f.Name)
+	if err != nil {
+		return err
+	}
+	sliceValue := cli.NewInt64Slice(value...)
+	for _, name := range f.Names() {
+		if underlyingFlag := f.set.Lookup(name); underlyingFlag != nil {
+			underlyingFlag.Value = sliceValue
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a Float64Slice value to the flagSet if required
+func (f *Float64SliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Float64Slice(f.Name)
+	if err != nil {
+		return err
+	}
+	sliceValue := cli.NewFloat64Slice(value...)
+	for _, name := range f.Names() {
+		if underlyingFlag := f.set.Lookup(name); underlyingFlag != nil {
+			underlyingFlag.Value = sliceValue
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a Bool value to the flagSet if required
+func (f *BoolFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Bool(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), strconv.FormatBool(value))
+}
+
+// ApplyInputSourceValue applies a String value to the flagSet if required
+func (f *StringFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.String(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), value)
+}
+
+// ApplyInputSourceValue applies a Path value to the flagSet if required
+func (f *PathFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.String(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), value)
+}
+
+// ApplyInputSourceValue applies a Timestamp value to the flagSet if required.
+// String values are parsed with the Layout of the flag.
+func (f *TimestampFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Timestamp(f.Name, f.Layout)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), value.Format(f.Layout))
+}
+
+// ApplyInputSourceValue applies a int value to the flagSet if required
+func (f *IntFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Int(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), strconv.Itoa(value))
+}
+
+// ApplyInputSourceValue applies a int64 value to the flagSet if required
+func (f *Int64Flag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Int64(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), strconv.FormatInt(value, 10))
+}
+
+// ApplyInputSourceValue applies a uint value to the flagSet if required
+func (f *UintFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Uint(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), strconv.FormatUint(uint64(value), 10))
+}
+
+// ApplyInputSourceValue applies a uint64 value to the flagSet if required
+func (f *Uint64Flag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Uint64(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), strconv.FormatUint(value, 10))
+}
+
+// ApplyInputSourceValue applies a Duration value to the flagSet if required
+func (f *DurationFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Duration(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), value.String())
+}
+
+// ApplyInputSourceValue applies a Float64 value to the flagSet if required
+func (f *Float64Flag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if !shouldApply(context, isc, f.set, f.Name, f.EnvVars) {
+		return nil
+	}
+
+	value, err := isc.Float64(f.Name)
+	if err != nil {
+		return err
+	}
+	return setAll(f.set, f.Names(), strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// shouldApply reports whether a value of the input source is to be applied to
+// the flag: the flag was applied to a flag set, the input source has a value
+// for it and neither the command line nor an environment variable set it.
+func shouldApply(context *cli.Context, isc InputSourceContext, set *flag.FlagSet, name string, envVars []string) bool {
+	return set != nil && isc.IsSet(name) && !context.IsSet(name) && !isEnvVarSet(envVars)
+}
+
+func setAll(set *flag.FlagSet, names []string, value string) error {
+	for _, name := range names {
+		if err := set.Set(name, value); err != nil {
+			return fmt.Errorf("could not apply %q to flag %s: %s", value, name, err)
+		}
+	}
+	return nil
+}
+
+func isEnvVarSet(envVars []string) bool {
+	for _, envVar := range envVars {
+		if _, ok := syscall.Getenv(envVar); ok {
+			// TODO: Can't use this for bools as
+			// set means that it was true or false based on
+			// Bool flag type, should work for other types
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,29 @@
+package altsrc
+
+import "time"
+
+// InputSourceContext is an interface used to allow
+// other input sources to be implemented as needed.
+//
+// Each method returns the zero value and a nil error when
+// the name is not present in the source.
+type InputSourceContext interface {
+	Source() string
+
+	Int(name string) (int, error)
+	Int64(name string) (int64, error)
+	Uint(name string) (uint, error)
+	Uint64(name string) (uint64, error)
+	Duration(name string) (time.Duration, error)
+	Timestamp(name, layout string) (time.Time, error)
+	Float64(name string) (float64, error)
+	String(name string) (string, error)
+	StringSlice(name string) ([]string, error)
+	IntSlice(name string) ([]int, error)
+	Int64Slice(name string) ([]int64, error)
+	Float64Slice(name string) ([]float64, error)
+	Bool(name string) (bool, error)
+
+	// IsSet reports whether the name is present in the source
+	IsSet(name string) bool
+}
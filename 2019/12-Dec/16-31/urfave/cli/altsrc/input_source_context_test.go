@@ -0,0 +1,59 @@
+package altsrc_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gy-kim/golang-daily-practice/2019/12-Dec/16-31/urfave/cli"
+	"github.com/gy-kim/golang-daily-practice/2019/12-Dec/16-31/urfave/cli/altsrc"
+)
+
+// staticSource is an input source implemented outside of altsrc, which
+// only holds string values
+type staticSource map[string]string
+
+func (s staticSource) Source() string                              { return "static" }
+func (s staticSource) Int(name string) (int, error)                { return 0, nil }
+func (s staticSource) Int64(name string) (int64, error)            { return 0, nil }
+func (s staticSource) Uint(name string) (uint, error)              { return 0, nil }
+func (s staticSource) Uint64(name string) (uint64, error)          { return 0, nil }
+func (s staticSource) Duration(name string) (time.Duration, error) { return 0, nil }
+func (s staticSource) Float64(name string) (float64, error)        { return 0, nil }
+func (s staticSource) String(name string) (string, error)          { return s[name], nil }
+func (s staticSource) StringSlice(name string) ([]string, error)   { return nil, nil }
+func (s staticSource) IntSlice(name string) ([]int, error)         { return nil, nil }
+func (s staticSource) Int64Slice(name string) ([]int64, error)     { return nil, nil }
+func (s staticSource) Float64Slice(name string) ([]float64, error) { return nil, nil }
+func (s staticSource) Bool(name string) (bool, error)              { return false, nil }
+func (s staticSource) IsSet(name string) bool                      { _, ok := s[name]; return ok }
+func (s staticSource) Timestamp(name, layout string) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func TestCustomInputSource(t *testing.T) {
+	flags := []cli.Flag{
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "name", Value: "default"}),
+		altsrc.NewStringFlag(&cli.StringFlag{Name: "other", Value: "default"}),
+	}
+
+	var name, other string
+	app := &cli.App{
+		Writer: &bytes.Buffer{},
+		Flags:  flags,
+		Before: altsrc.InitInputSource(flags, func() (altsrc.InputSourceContext, error) {
+			return staticSource{"name": "from-source"}, nil
+		}),
+		Action: func(c *cli.Context) error {
+			name, other = c.String("name"), c.String("other")
+			return nil
+		},
+	}
+
+	if err := app.Run([]string{"app"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	if name != "from-source" || other != "default" {
+		t.Errorf("expected name from the custom source and other default, but got: %q and %q", name, other)
+	}
+}
@@ -0,0 +1,84 @@
+package altsrc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/gy-kim/golang-daily-practice/2019/12-Dec/16-31/urfave/cli"
+)
+
+// NewJSONSourceFromFile returns an InputSourceContext suitable for
+// retrieving config variables from a file containing a JSON object.
+func NewJSONSourceFromFile(file string) (InputSourceContext, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load JSON file '%s': inner error: \n'%v'", file, err.Error())
+	}
+
+	return newJSONSource(file, data)
+}
+
+// NewJSONSource returns an InputSourceContext suitable for retrieving
+// config variables from raw JSON data.
+func NewJSONSource(data []byte) (InputSourceContext, error) {
+	return newJSONSource("", data)
+}
+
+// NewJSONSourceFromFlagFunc returns a func that takes a cli.Context
+// and returns an InputSourceContext suitable for retrieving config
+// variables from a file containing a JSON object with the file name
+// defined by the given flag. When the flag is empty, an empty source
+// is returned so that the other flags keep their defaults.
+func NewJSONSourceFromFlagFunc(flag string) func(context *cli.Context) (InputSourceContext, error) {
+	return func(context *cli.Context) (InputSourceContext, error) {
+		filePath := context.String(flag)
+		if filePath == "" {
+			return NewMapInputSource("", map[interface{}]interface{}{}), nil
+		}
+		return NewJSONSourceFromFile(filePath)
+	}
+}
+
+func newJSONSource(file string, data []byte) (InputSourceContext, error) {
+	results := map[string]interface{}{}
+
+	// numbers are decoded as json.Number so that large integers keep their precision
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&results); err != nil {
+		return nil, fmt.Errorf("Unable to parse JSON '%s': inner error: \n'%v'", file, err.Error())
+	}
+
+	return NewMapInputSource(file, toInterfaceMap(jsonNumbers(results).(map[string]interface{}))), nil
+}
+
+// jsonNumbers replaces every json.Number with an int64, an uint64 or a float64,
+// whichever holds the value without loss.
+func jsonNumbers(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = jsonNumbers(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonNumbers(item)
+		}
+		return v
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return n
+		}
+		if n, err := v.Float64(); err == nil {
+			return n
+		}
+	}
+	return val
+}
@@ -0,0 +1,331 @@
+package altsrc
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// MapInputSource implements InputSourceContext to return
+// data from the map that is loaded.
+type MapInputSource struct {
+	file     string
+	valueMap map[interface{}]interface{}
+}
+
+// NewMapInputSource creates a new MapInputSource for implementing custom input sources.
+func NewMapInputSource(file string, valueMap map[interface{}]interface{}) *MapInputSource {
+	return &MapInputSource{
+		file:     file,
+		valueMap: valueMap,
+	}
+}
+
+// nestedVal checks if the name has '.' delimiters.
+// If so, it tries to traverse the tree by the '.' delimited sections to find
+// a nested value for the key.
+func nestedVal(name string, tree map[interface{}]interface{}) (interface{}, bool) {
+	if sections := strings.Split(name, "."); len(sections) > 1 {
+		node := tree
+		for _, section := range sections[:len(sections)-1] {
+			child, ok := node[section]
+			if !ok {
+				return nil, false
+			}
+			ctype, ok := child.(map[interface{}]interface{})
+			if !ok {
+				return nil, false
+			}
+			node = ctype
+		}
+		if val, ok := node[sections[len(sections)-1]]; ok {
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+func (fsm *MapInputSource) lookup(name string) (interface{}, bool) {
+	if val, ok := fsm.valueMap[name]; ok {
+		return val, true
+	}
+	return nestedVal(name, fsm.valueMap)
+}
+
+// Source returns the path of the source file
+func (fsm *MapInputSource) Source() string {
+	return fsm.file
+}
+
+// Int returns an int from the map if it exists otherwise returns 0
+func (fsm *MapInputSource) Int(name string) (int, error) {
+	val, err := fsm.Int64(name)
+	return int(val), err
+}
+
+// Int64 returns an int64 from the map if it exists otherwise returns 0
+func (fsm *MapInputSource) Int64(name string) (int64, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return 0, nil
+	}
+
+	otherValue, ok := toInt64(otherGenericValue)
+	if !ok {
+		return 0, incorrectTypeForFlagError(name, "int", otherGenericValue)
+	}
+	return otherValue, nil
+}
+
+// Uint returns an uint from the map if it exists otherwise returns 0
+func (fsm *MapInputSource) Uint(name string) (uint, error) {
+	val, err := fsm.Uint64(name)
+	return uint(val), err
+}
+
+// Uint64 returns an uint64 from the map if it exists otherwise returns 0
+func (fsm *MapInputSource) Uint64(name string) (uint64, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return 0, nil
+	}
+
+	if otherValue, ok := otherGenericValue.(uint64); ok {
+		return otherValue, nil
+	}
+	otherValue, ok := toInt64(otherGenericValue)
+	if !ok || otherValue < 0 {
+		return 0, incorrectTypeForFlagError(name, "uint", otherGenericValue)
+	}
+	return uint64(otherValue), nil
+}
+
+// Duration returns a duration from the map if it exists otherwise returns 0
+func (fsm *MapInputSource) Duration(name string) (time.Duration, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return 0, nil
+	}
+
+	switch otherValue := otherGenericValue.(type) {
+	case time.Duration:
+		return otherValue, nil
+	case string:
+		parsed, err := time.ParseDuration(otherValue)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse %q as duration for flag %s: %s", otherValue, name, err)
+		}
+		return parsed, nil
+	}
+	return 0, incorrectTypeForFlagError(name, "duration", otherGenericValue)
+}
+
+// Timestamp returns a time from the map if it exists otherwise returns
+// the zero time. Native timestamps, like TOML datetimes, are returned as
+// is and strings are parsed with layout.
+func (fsm *MapInputSource) Timestamp(name, layout string) (time.Time, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return time.Time{}, nil
+	}
+
+	switch otherValue := otherGenericValue.(type) {
+	case time.Time:
+		return otherValue, nil
+	case string:
+		parsed, err := time.Parse(layout, otherValue)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("could not parse %q as timestamp for flag %s: %s", otherValue, name, err)
+		}
+		return parsed, nil
+	}
+	return time.Time{}, incorrectTypeForFlagError(name, "timestamp", otherGenericValue)
+}
+
+// Float64 returns an float64 from the map if it exists otherwise returns 0
+func (fsm *MapInputSource) Float64(name string) (float64, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return 0, nil
+	}
+
+	otherValue, ok := toFloat64(otherGenericValue)
+	if !ok {
+		return 0, incorrectTypeForFlagError(name, "float64", otherGenericValue)
+	}
+	return otherValue, nil
+}
+
+// String returns a string from the map if it exists otherwise returns an empty string
+func (fsm *MapInputSource) String(name string) (string, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return "", nil
+	}
+
+	otherValue, ok := otherGenericValue.(string)
+	if !ok {
+		return "", incorrectTypeForFlagError(name, "string", otherGenericValue)
+	}
+	return otherValue, nil
+}
+
+// StringSlice returns an []string from the map if it exists otherwise returns nil
+func (fsm *MapInputSource) StringSlice(name string) ([]string, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return nil, nil
+	}
+
+	if otherValue, ok := otherGenericValue.([]string); ok {
+		return otherValue, nil
+	}
+
+	otherValue, ok := otherGenericValue.([]interface{})
+	if !ok {
+		return nil, incorrectTypeForFlagError(name, "[]interface{}", otherGenericValue)
+	}
+
+	var stringSlice = make([]string, 0, len(otherValue))
+	for i, v := range otherValue {
+		stringValue, ok := v.(string)
+		if !ok {
+			return nil, incorrectTypeForFlagError(fmt.Sprintf("%s[%d]", name, i), "string", v)
+		}
+		stringSlice = append(stringSlice, stringValue)
+	}
+
+	return stringSlice, nil
+}
+
+// IntSlice returns an []int from the map if it exists otherwise returns nil
+func (fsm *MapInputSource) IntSlice(name string) ([]int, error) {
+	int64Slice, err := fsm.Int64Slice(name)
+	if int64Slice == nil || err != nil {
+		return nil, err
+	}
+
+	var intSlice = make([]int, 0, len(int64Slice))
+	for _, v := range int64Slice {
+		intSlice = append(intSlice, int(v))
+	}
+	return intSlice, nil
+}
+
+// Int64Slice returns an []int64 from the map if it exists otherwise returns nil
+func (fsm *MapInputSource) Int64Slice(name string) ([]int64, error) {
+	otherValue, err := fsm.slice(name)
+	if otherValue == nil || err != nil {
+		return nil, err
+	}
+
+	var int64Slice = make([]int64, 0, len(otherValue))
+	for i, v := range otherValue {
+		intValue, ok := toInt64(v)
+		if !ok {
+			return nil, incorrectTypeForFlagError(fmt.Sprintf("%s[%d]", name, i), "int", v)
+		}
+		int64Slice = append(int64Slice, intValue)
+	}
+	return int64Slice, nil
+}
+
+// Float64Slice returns an []float64 from the map if it exists otherwise returns nil
+func (fsm *MapInputSource) Float64Slice(name string) ([]float64, error) {
+	otherValue, err := fsm.slice(name)
+	if otherValue == nil || err != nil {
+		return nil, err
+	}
+
+	var float64Slice = make([]float64, 0, len(otherValue))
+	for i, v := range otherValue {
+		floatValue, ok := toFloat64(v)
+		if !ok {
+			return nil, incorrectTypeForFlagError(fmt.Sprintf("%s[%d]", name, i), "float64", v)
+		}
+		float64Slice = append(float64Slice, floatValue)
+	}
+	return float64Slice, nil
+}
+
+func (fsm *MapInputSource) slice(name string) ([]interface{}, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return nil, nil
+	}
+
+	otherValue, ok := otherGenericValue.([]interface{})
+	if !ok {
+		return nil, incorrectTypeForFlagError(name, "[]interface{}", otherGenericValue)
+	}
+	return otherValue, nil
+}
+
+// Bool returns an bool from the map otherwise returns false
+func (fsm *MapInputSource) Bool(name string) (bool, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return false, nil
+	}
+
+	otherValue, ok := otherGenericValue.(bool)
+	if !ok {
+		return false, incorrectTypeForFlagError(name, "bool", otherGenericValue)
+	}
+	return otherValue, nil
+}
+
+// IsSet reports whether the name is present in the map
+func (fsm *MapInputSource) IsSet(name string) bool {
+	_, exists := fsm.lookup(name)
+	return exists
+}
+
+// toInt64 converts the numeric types produced by the YAML, TOML and JSON
+// decoders to an int64. Floats are only accepted without a fraction, so
+// that whole numbers written as floats, like 8080.0, are accepted too.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint64:
+		if n > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(n), true
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, false
+		}
+		return int64(n), true
+	}
+	return 0, false
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func incorrectTypeForFlagError(name, expectedTypeName string, value interface{}) error {
+	valueType := reflect.TypeOf(value)
+	valueTypeName := ""
+	if valueType != nil {
+		valueTypeName = valueType.Name()
+	}
+
+	return fmt.Errorf("Mismatched type for flag '%s'. Expected '%s' but actual is '%s'", name, expectedTypeName, valueTypeName)
+}
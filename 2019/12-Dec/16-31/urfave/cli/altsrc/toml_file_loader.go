@@ -0,0 +1,61 @@
+package altsrc
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gy-kim/golang-daily-practice/2019/12-Dec/16-31/urfave/cli"
+)
+
+// NewTomlSourceFromFile creates a new TOML InputSourceContext from a filepath.
+func NewTomlSourceFromFile(file string) (InputSourceContext, error) {
+	results := map[string]interface{}{}
+	if _, err := toml.DecodeFile(file, &results); err != nil {
+		return nil, fmt.Errorf("Unable to load TOML file '%s': inner error: \n'%v'", file, err.Error())
+	}
+
+	return NewMapInputSource(file, toInterfaceMap(results)), nil
+}
+
+// NewTomlSourceFromFlagFunc creates a new TOML InputSourceContext from a provided flag name and source context.
+// When the flag is empty, an empty source is returned so that the other flags keep their defaults.
+func NewTomlSourceFromFlagFunc(flagFileName string) func(context *cli.Context) (InputSourceContext, error) {
+	return func(context *cli.Context) (InputSourceContext, error) {
+		filePath := context.String(flagFileName)
+		if filePath == "" {
+			return NewMapInputSource("", map[interface{}]interface{}{}), nil
+		}
+		return NewTomlSourceFromFile(filePath)
+	}
+}
+
+// toInterfaceMap converts the string keyed maps produced by the TOML and JSON
+// decoders to the interface keyed maps MapInputSource walks, the same shape
+// the Yaml decoder produces.
+func toInterfaceMap(m map[string]interface{}) map[interface{}]interface{} {
+	ret := make(map[interface{}]interface{}, len(m))
+	for key, val := range m {
+		ret[key] = toInterfaceValue(val)
+	}
+	return ret
+}
+
+func toInterfaceValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return toInterfaceMap(v)
+	case []map[string]interface{}:
+		ret := make([]interface{}, len(v))
+		for i, m := range v {
+			ret[i] = toInterfaceMap(m)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, item := range v {
+			ret[i] = toInterfaceValue(item)
+		}
+		return ret
+	}
+	return val
+}
@@ -0,0 +1,36 @@
+package altsrc
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/gy-kim/golang-daily-practice/2019/12-Dec/16-31/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// NewYamlSourceFromFile creates a new Yaml InputSourceContext from a filepath.
+func NewYamlSourceFromFile(file string) (InputSourceContext, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load Yaml file '%s': inner error: \n'%v'", file, err.Error())
+	}
+
+	results := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("Unable to parse Yaml file '%s': inner error: \n'%v'", file, err.Error())
+	}
+
+	return NewMapInputSource(file, results), nil
+}
+
+// NewYamlSourceFromFlagFunc creates a new Yaml InputSourceContext from a provided flag name and source context.
+// When the flag is empty, an empty source is returned so that the other flags keep their defaults.
+func NewYamlSourceFromFlagFunc(flagFileName string) func(context *cli.Context) (InputSourceContext, error) {
+	return func(context *cli.Context) (InputSourceContext, error) {
+		filePath := context.String(flagFileName)
+		if filePath == "" {
+			return NewMapInputSource("", map[interface{}]interface{}{}), nil
+		}
+		return NewYamlSourceFromFile(filePath)
+	}
+}
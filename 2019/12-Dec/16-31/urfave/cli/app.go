@@ -1,7 +1,13 @@
 package cli
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"time"
 )
 
@@ -35,6 +41,12 @@ type App struct {
 	// List of flags to parse
 	Flags []Flag
 
+	// Groups of mutually exclusive flags
+	FlagGroups []FlagGroup
+
+	// Flags that may only be set together with other flags
+	FlagDependencies []FlagDependency
+
 	EnableBashCompletion bool
 
 	HideHelp bool
@@ -49,6 +61,8 @@ type App struct {
 
 	After AfterFunc
 
+	Action ActionFunc
+
 	CommandNotFound CommandNotFoundFunc
 
 	OnUsageError OnUsageErrorFunc
@@ -59,12 +73,23 @@ type App struct {
 
 	Copyright string
 
+	Reader io.Reader
+
 	Writer io.Writer
 
+	// ErrWriter is where errors are reported, defaulting to the package
+	// level ErrWriter
 	ErrWriter io.Writer
 
 	ExitErrHandler ExitErrHandlerFunc
 
+	// ErrorFormat is ErrorFormatText (the default) or ErrorFormatJSON
+	ErrorFormat string
+
+	// OsExiter is called with the exit code of ExitCoder errors, defaulting
+	// to the package level OsExiter
+	OsExiter func(code int)
+
 	Metadata map[string]interface{}
 
 	ExtraInfo func() map[string]string
@@ -73,7 +98,444 @@ type App struct {
 
 	UseShortOptionHandling bool
 
+	// AllowInterspersed enables GNU-style parsing, where flags given after
+	// positional arguments are honored. Arguments after a "--" terminator
+	// are never parsed as flags.
+	AllowInterspersed bool
+
+	// PromptForMissing asks for the values of missing required flags on
+	// Writer, reading them from Reader, when Reader is a terminal
+	PromptForMissing bool
+
+	// Suggest enables "did you mean" suggestions for unknown commands and
+	// undefined flags
+	Suggest bool
+
+	// SuggestCommandFunc overrides the default Jaro-Winkler based command
+	// suggestion
+	SuggestCommandFunc SuggestCommandFunc
+
+	// SuggestFlagFunc overrides the default Jaro-Winkler based flag
+	// suggestion
+	SuggestFlagFunc SuggestFlagFunc
+
 	didSetup bool
+
+	// bindings of the command run as this app, see Command.bindings
+	bindings []func(*Context)
+}
+
+// NewApp creates a new cli Application with some reasonable defaults for Name,
+// Usage, Version and Action.
+func NewApp() *App {
+	return &App{
+		Name:         filepath.Base(os.Args[0]),
+		HelpName:     filepath.Base(os.Args[0]),
+		Usage:        "A new cli application",
+		UsageText:    "",
+		BashComplete: DefaultAppComplete,
+		Action:       helpCommand.Action,
+		Compiled:     compileTime(),
+		Reader:       os.Stdin,
+		Writer:       os.Stdout,
+	}
+}
+
+// Setup runs initialization code to ensure all data structures are ready for
+// `Run` or inspection prior to `Run`. It is internally called by `Run`, but
+// will return early if setup has already happened.
+func (a *App) Setup() {
+	if a.didSetup {
+		return
+	}
+
+	a.didSetup = true
+
+	if a.Name == "" {
+		a.Name = filepath.Base(os.Args[0])
+	}
+
+	if a.HelpName == "" {
+		a.HelpName = filepath.Base(os.Args[0])
+	}
+
+	if a.Usage == "" {
+		a.Usage = "A new cli application"
+	}
+
+	if a.Version == "" {
+		a.HideVersion = true
+	}
+
+	if a.BashComplete == nil {
+		a.BashComplete = DefaultAppComplete
+	}
+
+	if a.Action == nil {
+		a.Action = helpCommand.Action
+	}
+
+	if a.Compiled == (time.Time{}) {
+		a.Compiled = compileTime()
+	}
+
+	if a.Reader == nil {
+		a.Reader = os.Stdin
+	}
+
+	if a.Writer == nil {
+		a.Writer = os.Stdout
+	}
+
+	var newCommands []*Command
+
+	for _, c := range a.Commands {
+		if c.HelpName == "" {
+			c.HelpName = fmt.Sprintf("%s %s", a.HelpName, c.Name)
+		}
+		if c.ArgsUsage == "" && len(c.Arguments) > 0 {
+			c.ArgsUsage = argumentsUsage(c.Arguments)
+		}
+		newCommands = append(newCommands, c)
+	}
+	a.Commands = newCommands
+
+	if a.Command(helpCommand.Name) == nil && !a.HideHelp {
+		a.appendCommand(helpCommand)
+
+		if HelpFlag != nil {
+			a.appendFlag(HelpFlag)
+		}
+	}
+
+	if !a.HideVersion {
+		a.appendFlag(VersionFlag)
+	}
+
+	if a.EnableBashCompletion && a.Command(completionCommand.Name) == nil {
+		a.appendCommand(completionCommand)
+	}
+
+	a.categories = newCommandCategories()
+	for _, command := range a.Commands {
+		a.categories.AddCommand(command.Category, command)
+	}
+	sort.Sort(a.categories.(*commandCategories))
+
+	if a.Metadata == nil {
+		a.Metadata = make(map[string]interface{})
+	}
+}
+
+func (a *App) newFlagSet() (*flag.FlagSet, error) {
+	return flagSet(a.Name, a.Flags)
+}
+
+func (a *App) useShortOptionHandling() bool {
+	return a.UseShortOptionHandling
+}
+
+func (a *App) allowInterspersed() bool {
+	return a.AllowInterspersed
+}
+
+func (a *App) isSubcommand(name string) bool {
+	return a.Command(name) != nil
+}
+
+// Run is the entry point to the cli app. Parses the arguments slice and routes
+// to the proper flag/args combination
+func (a *App) Run(arguments []string) (err error) {
+	return a.RunContext(context.Background(), arguments)
+}
+
+// RunContext is like Run except it takes a Context that will be
+// passed to its commands and sub-commands. Through this, you can
+// propagate timeouts and cancellation requests
+func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
+	a.Setup()
+
+	// handle the completion flag separately from the flagset since
+	// completion could be attempted after a flag, but before its value was put
+	// on the command line. this causes the flagset to interpret the completion
+	// flag name as the value of the flag before it which is undesirable
+	// note that we can only do this because the shell autocomplete function
+	// always appends the completion flag at the end of the command
+	runArgs := arguments
+	shellComplete, arguments := checkShellCompleteFlag(a, arguments)
+
+	set, err := a.newFlagSet()
+	if err != nil {
+		return err
+	}
+
+	var tail []string
+	if len(arguments) > 0 {
+		tail = arguments[1:]
+	}
+
+	err = parseIter(set, a, tail, shellComplete)
+	nerr := normalizeFlags(a.Flags, set)
+	context := NewContext(a, set, &Context{Context: ctx, runArgs: runArgs})
+	if nerr != nil {
+		if !a.reportUsageError(nerr) {
+			_, _ = fmt.Fprintln(a.Writer, nerr)
+			_ = ShowAppHelp(context)
+		}
+		return nerr
+	}
+	context.shellComplete = shellComplete
+
+	if checkCompletions(context) {
+		return nil
+	}
+
+	if err != nil {
+		if a.OnUsageError != nil {
+			err := a.OnUsageError(context, err, false)
+			a.handleExitCoder(context, err)
+			return err
+		}
+		if a.reportUsageError(err) {
+			return err
+		}
+		_, _ = fmt.Fprintf(a.Writer, "%s %s\n\n", "Incorrect Usage.", err.Error())
+		if suggestion := a.suggestFlagMessage(a.Flags, err); suggestion != "" {
+			_, _ = fmt.Fprintf(a.Writer, "%s\n\n", suggestion)
+		}
+		_ = ShowAppHelp(context)
+		return err
+	}
+
+	if !a.HideHelp && checkHelp(context) {
+		_ = ShowAppHelp(context)
+		return nil
+	}
+
+	if !a.HideVersion && checkVersion(context) {
+		ShowVersion(context)
+		return nil
+	}
+
+	a.promptForMissingFlags(a.Flags, context)
+
+	cerr := checkFlagConstraints(a.Flags, a.FlagGroups, a.FlagDependencies, context)
+	if cerr != nil {
+		if !a.reportUsageError(cerr) {
+			_ = ShowAppHelp(context)
+		}
+		return cerr
+	}
+
+	if a.After != nil {
+		defer func() {
+			if afterErr := a.After(context); afterErr != nil {
+				if err != nil {
+					err = newMultiError(err, afterErr)
+				} else {
+					err = afterErr
+				}
+			}
+		}()
+	}
+
+	if a.Before != nil {
+		beforeErr := a.Before(context)
+		if beforeErr != nil {
+			_, _ = fmt.Fprintf(a.Writer, "%v\n\n", beforeErr)
+			_ = ShowAppHelp(context)
+			a.handleExitCoder(context, beforeErr)
+			err = beforeErr
+			return err
+		}
+	}
+
+	args := context.Args()
+	if args.Present() {
+		name := args.First()
+		c := a.Command(name)
+		if c != nil {
+			return c.Run(context)
+		}
+	}
+
+	// Run default Action
+	err = a.Action(context)
+
+	a.handleExitCoder(context, err)
+	return err
+}
+
+// RunAsSubcommand invokes the subcommand given the context, parses ctx.Args() to
+// generate command-specific flags
+func (a *App) RunAsSubcommand(ctx *Context) (err error) {
+	a.Setup()
+
+	set, err := a.newFlagSet()
+	if err != nil {
+		return err
+	}
+
+	err = parseIter(set, a, ctx.Args().Tail(), ctx.shellComplete)
+	nerr := normalizeFlags(a.Flags, set)
+	context := NewContext(a, set, ctx)
+
+	if nerr != nil {
+		if a.reportUsageError(nerr) {
+			return nerr
+		}
+		_, _ = fmt.Fprintln(a.Writer, nerr)
+		_, _ = fmt.Fprintln(a.Writer)
+		if len(a.Commands) > 0 {
+			_ = ShowSubcommandHelp(context)
+		} else {
+			_ = ShowCommandHelp(ctx, context.Args().First())
+		}
+		return nerr
+	}
+
+	if checkCompletions(context) {
+		return nil
+	}
+
+	if err != nil {
+		if a.OnUsageError != nil {
+			err = a.OnUsageError(context, err, true)
+			a.handleExitCoder(context, err)
+			return err
+		}
+		if a.reportUsageError(err) {
+			return err
+		}
+		_, _ = fmt.Fprintf(a.Writer, "%s %s\n\n", "Incorrect Usage.", err.Error())
+		if suggestion := a.suggestFlagMessage(a.Flags, err); suggestion != "" {
+			_, _ = fmt.Fprintf(a.Writer, "%s\n\n", suggestion)
+		}
+		_ = ShowSubcommandHelp(context)
+		return err
+	}
+
+	if len(a.Commands) > 0 {
+		if checkSubcommandHelp(context) {
+			return nil
+		}
+	} else {
+		if checkCommandHelp(ctx, context.Args().First()) {
+			return nil
+		}
+	}
+
+	a.promptForMissingFlags(a.Flags, context)
+
+	cerr := checkFlagConstraints(a.Flags, a.FlagGroups, a.FlagDependencies, context)
+	if cerr != nil {
+		if !a.reportUsageError(cerr) {
+			_ = ShowSubcommandHelp(context)
+		}
+		return cerr
+	}
+
+	if a.After != nil {
+		defer func() {
+			afterErr := a.After(context)
+			if afterErr != nil {
+				a.handleExitCoder(context, err)
+				if err != nil {
+					err = newMultiError(err, afterErr)
+				} else {
+					err = afterErr
+				}
+			}
+		}()
+	}
+
+	if a.Before != nil {
+		beforeErr := a.Before(context)
+		if beforeErr != nil {
+			a.handleExitCoder(context, beforeErr)
+			err = beforeErr
+			return err
+		}
+	}
+
+	// bind after Before, which may set flags from other sources
+	for _, bind := range a.bindings {
+		bind(context)
+	}
+
+	args := context.Args()
+	if args.Present() {
+		name := args.First()
+		c := a.Command(name)
+		if c != nil {
+			return c.Run(context)
+		}
+	}
+
+	// Run default Action
+	err = a.Action(context)
+
+	a.handleExitCoder(context, err)
+	return err
+}
+
+// Command returns the named command on App. Returns nil if the command does not exist
+func (a *App) Command(name string) *Command {
+	for _, c := range a.Commands {
+		if c.HasName(name) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// VisibleCategories returns a slice of categories and commands that are
+// Hidden=false
+func (a *App) VisibleCategories() []CommandCategory {
+	ret := []CommandCategory{}
+	for _, category := range a.categories.Categories() {
+		if len(category.VisibleCommands()) > 0 {
+			ret = append(ret, category)
+		}
+	}
+	return ret
+}
+
+// VisibleCommands returns a slice of the Commands with Hidden=false
+func (a *App) VisibleCommands() []*Command {
+	var ret []*Command
+	for _, command := range a.Commands {
+		if !command.Hidden {
+			ret = append(ret, command)
+		}
+	}
+	return ret
+}
+
+// VisibleFlags returns a slice of the Flags with Hidden=false
+func (a *App) VisibleFlags() []Flag {
+	return visibleFlags(a.Flags)
+}
+
+func (a *App) appendFlag(fl Flag) {
+	if !hasFlag(a.Flags, fl) {
+		a.Flags = append(a.Flags, fl)
+	}
+}
+
+func (a *App) appendCommand(c *Command) {
+	if !hasCommand(a.Commands, c) {
+		a.Commands = append(a.Commands, c)
+	}
+}
+
+func (a *App) handleExitCoder(context *Context, err error) {
+	if a.ExitErrHandler != nil {
+		a.ExitErrHandler(context, err)
+	} else {
+		a.reportExitCoder(err)
+	}
 }
 
 // Author represents someone who
@@ -81,3 +543,23 @@ type Author struct {
 	Name  string
 	Email string
 }
+
+// String makes Author comply to the Stringer interface, to allow an easy print in the templating process
+func (a *Author) String() string {
+	e := ""
+	if a.Email != "" {
+		e = " <" + a.Email + ">"
+	}
+
+	return fmt.Sprintf("%v%v", a.Name, e)
+}
+
+// Tries to find out when this binary was compiled.
+// Returns the current time if it fails to find it.
+func compileTime() time.Time {
+	info, err := os.Stat(os.Args[0])
+	if err != nil {
+		return time.Now()
+	}
+	return info.ModTime()
+}
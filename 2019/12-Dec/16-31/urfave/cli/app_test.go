@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAppRunDispatchesToSubcommand(t *testing.T) {
+	var got []string
+	var verbose bool
+
+	app := &App{
+		Name:   "app",
+		Writer: &bytes.Buffer{},
+		Flags:  []Flag{&BoolFlag{Name: "verbose", Aliases: []string{"V"}}},
+		Commands: []*Command{
+			{
+				Name: "remote",
+				Subcommands: []*Command{
+					{
+						Name:    "add",
+						Aliases: []string{"a"},
+						Flags:   []Flag{&StringSliceFlag{Name: "tag"}},
+						Action: func(c *Context) error {
+							got = append(c.StringSlice("tag"), c.Args().Slice()...)
+							verbose = c.Bool("verbose")
+							return nil
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := app.Run([]string{"app", "-V", "remote", "a", "--tag", "x", "origin"})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	if len(got) != 2 || got[0] != "x" || got[1] != "origin" {
+		t.Errorf("expected [x origin], but got: %v", got)
+	}
+	if !verbose {
+		t.Error("expected parent flag verbose to be visible from subcommand")
+	}
+}
+
+func TestAppRunChecksRequiredFlags(t *testing.T) {
+	app := &App{
+		Writer: &bytes.Buffer{},
+		Commands: []*Command{
+			{
+				Name:   "get",
+				Flags:  []Flag{&IntFlag{Name: "id", Required: true}},
+				Action: func(c *Context) error { return nil },
+			},
+		},
+	}
+
+	err := app.Run([]string{"app", "get"})
+	if _, ok := err.(requiredFlagsErr); !ok {
+		t.Fatalf("expected required flags error, but got: %v", err)
+	}
+}
+
+func TestAppRunAfterIsCalledWhenActionPanics(t *testing.T) {
+	afterCalled := false
+	app := &App{
+		Writer: &bytes.Buffer{},
+		After: func(c *Context) error {
+			afterCalled = true
+			return nil
+		},
+		Action: func(c *Context) error {
+			panic("action panic")
+		},
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic to propagate")
+		}
+		if !afterCalled {
+			t.Error("expected After to be called")
+		}
+	}()
+	_ = app.Run([]string{"app"})
+}
+
+func TestAppRunHandlesExitCoder(t *testing.T) {
+	var handled error
+	app := &App{
+		Writer: &bytes.Buffer{},
+		ExitErrHandler: func(c *Context, err error) {
+			handled = err
+		},
+		After: func(c *Context) error {
+			return errors.New("after failed")
+		},
+		Action: func(c *Context) error {
+			return Exit("action failed", 3)
+		},
+	}
+
+	err := app.Run([]string{"app"})
+	if handled == nil || handled.(ExitCoder).ExitCode() != 3 {
+		t.Errorf("expected exit coder with code 3 to be handled, but got: %v", handled)
+	}
+	multiErr, ok := err.(MultiError)
+	if !ok || len(multiErr.Errors()) != 2 {
+		t.Errorf("expected action and after errors to be combined, but got: %v", err)
+	}
+}
+
+func TestAppRunContextPropagatesContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+
+	var got interface{}
+	app := &App{
+		Writer: &bytes.Buffer{},
+		Commands: []*Command{
+			{
+				Name: "cmd",
+				Action: func(c *Context) error {
+					got = c.Context.Value(key{})
+					return nil
+				},
+			},
+		},
+	}
+
+	if err := app.RunContext(ctx, []string{"app", "cmd"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	if got != "value" {
+		t.Errorf("expected context value to be propagated, but got: %v", got)
+	}
+}
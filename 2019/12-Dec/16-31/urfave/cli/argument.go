@@ -0,0 +1,328 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Argument is a positional argument definition of a Command. The positional
+// values are matched against the arguments in order, each argument taking
+// between its minimum and maximum number of values.
+type Argument interface {
+	fmt.Stringer
+
+	// ArgName returns the name used in the usage text and by the Context
+	// accessors
+	ArgName() string
+
+	// Counts returns the minimum and maximum number of values the argument
+	// takes, a negative maximum meaning no limit
+	Counts() (min, max int)
+
+	// Parse converts the values taken by the argument
+	Parse(values []string) (interface{}, error)
+}
+
+// StringArg is a positional argument of type string. Min is the minimum
+// number of values, zero making the argument optional. Max is the maximum
+// number of values, zero meaning one and a negative value making the
+// argument variadic.
+type StringArg struct {
+	Name  string
+	Usage string
+	Min   int
+	Max   int
+}
+
+// String returns a readable representation of this argument (for usage)
+func (a *StringArg) String() string {
+	return stringifyArgument(a, a.Usage)
+}
+
+// ArgName returns the name of the argument
+func (a *StringArg) ArgName() string {
+	return a.Name
+}
+
+// Counts returns the minimum and maximum number of values
+func (a *StringArg) Counts() (int, int) {
+	return argumentCounts(a.Min, a.Max)
+}
+
+// Parse returns the values as a []string
+func (a *StringArg) Parse(values []string) (interface{}, error) {
+	ret := make([]string, len(values))
+	copy(ret, values)
+	return ret, nil
+}
+
+// IntArg is a positional argument of type int. Min and Max work as in
+// StringArg.
+type IntArg struct {
+	Name  string
+	Usage string
+	Min   int
+	Max   int
+}
+
+// String returns a readable representation of this argument (for usage)
+func (a *IntArg) String() string {
+	return stringifyArgument(a, a.Usage)
+}
+
+// ArgName returns the name of the argument
+func (a *IntArg) ArgName() string {
+	return a.Name
+}
+
+// Counts returns the minimum and maximum number of values
+func (a *IntArg) Counts() (int, int) {
+	return argumentCounts(a.Min, a.Max)
+}
+
+// Parse parses the values as a []int
+func (a *IntArg) Parse(values []string) (interface{}, error) {
+	ret := make([]int, 0, len(values))
+	for _, v := range values {
+		i, err := strconv.ParseInt(v, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, int(i))
+	}
+	return ret, nil
+}
+
+// Float64Arg is a positional argument of type float64. Min and Max work as
+// in StringArg.
+type Float64Arg struct {
+	Name  string
+	Usage string
+	Min   int
+	Max   int
+}
+
+// String returns a readable representation of this argument (for usage)
+func (a *Float64Arg) String() string {
+	return stringifyArgument(a, a.Usage)
+}
+
+// ArgName returns the name of the argument
+func (a *Float64Arg) ArgName() string {
+	return a.Name
+}
+
+// Counts returns the minimum and maximum number of values
+func (a *Float64Arg) Counts() (int, int) {
+	return argumentCounts(a.Min, a.Max)
+}
+
+// Parse parses the values as a []float64
+func (a *Float64Arg) Parse(values []string) (interface{}, error) {
+	ret := make([]float64, 0, len(values))
+	for _, v := range values {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, f)
+	}
+	return ret, nil
+}
+
+// TimestampArg is a positional argument of type time.Time. Layout is
+// required and follows the time.Parse reference layout. Min and Max work
+// as in StringArg.
+type TimestampArg struct {
+	Name   string
+	Usage  string
+	Min    int
+	Max    int
+	Layout string
+}
+
+// String returns a readable representation of this argument (for usage)
+func (a *TimestampArg) String() string {
+	return stringifyArgument(a, a.Usage)
+}
+
+// ArgName returns the name of the argument
+func (a *TimestampArg) ArgName() string {
+	return a.Name
+}
+
+// Counts returns the minimum and maximum number of values
+func (a *TimestampArg) Counts() (int, int) {
+	return argumentCounts(a.Min, a.Max)
+}
+
+// Parse parses the values with Layout as a []time.Time
+func (a *TimestampArg) Parse(values []string) (interface{}, error) {
+	if a.Layout == "" {
+		return nil, fmt.Errorf("timestamp argument %q has no layout", a.Name)
+	}
+
+	ret := make([]time.Time, 0, len(values))
+	for _, v := range values {
+		t, err := time.Parse(a.Layout, v)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+// StringArg looks up the first value of a string argument
+func (c *Context) StringArg(name string) string {
+	if values := c.StringArgs(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// StringArgs looks up the values of a string argument
+func (c *Context) StringArgs(name string) []string {
+	if values, ok := c.arguments[name].([]string); ok {
+		return values
+	}
+	return nil
+}
+
+// IntArg looks up the first value of an int argument
+func (c *Context) IntArg(name string) int {
+	if values := c.IntArgs(name); len(values) > 0 {
+		return values[0]
+	}
+	return 0
+}
+
+// IntArgs looks up the values of an int argument
+func (c *Context) IntArgs(name string) []int {
+	if values, ok := c.arguments[name].([]int); ok {
+		return values
+	}
+	return nil
+}
+
+// Float64Arg looks up the first value of a float64 argument
+func (c *Context) Float64Arg(name string) float64 {
+	if values := c.Float64Args(name); len(values) > 0 {
+		return values[0]
+	}
+	return 0
+}
+
+// Float64Args looks up the values of a float64 argument
+func (c *Context) Float64Args(name string) []float64 {
+	if values, ok := c.arguments[name].([]float64); ok {
+		return values
+	}
+	return nil
+}
+
+// TimestampArg looks up the first value of a timestamp argument, or nil if
+// it was not given
+func (c *Context) TimestampArg(name string) *time.Time {
+	if values := c.TimestampArgs(name); len(values) > 0 {
+		return &values[0]
+	}
+	return nil
+}
+
+// TimestampArgs looks up the values of a timestamp argument
+func (c *Context) TimestampArgs(name string) []time.Time {
+	if values, ok := c.arguments[name].([]time.Time); ok {
+		return values
+	}
+	return nil
+}
+
+func argumentCounts(min, max int) (int, int) {
+	if max == 0 {
+		max = 1
+	}
+	if max > 0 && min > max {
+		max = min
+	}
+	return min, max
+}
+
+// argumentUsage returns the usage of a single argument, like "name",
+// "[name]", "name..." or "[name...]".
+func argumentUsage(a Argument) string {
+	min, max := a.Counts()
+	usage := a.ArgName()
+	if max < 0 || max > 1 {
+		usage += "..."
+	}
+	if min == 0 {
+		usage = "[" + usage + "]"
+	}
+	return usage
+}
+
+func stringifyArgument(a Argument, usage string) string {
+	return fmt.Sprintf("%s\t%s", argumentUsage(a), usage)
+}
+
+// argumentsUsage returns the usage text for arguments, used as the default
+// ArgsUsage of a command.
+func argumentsUsage(arguments []Argument) string {
+	usages := make([]string, 0, len(arguments))
+	for _, a := range arguments {
+		usages = append(usages, argumentUsage(a))
+	}
+	return strings.Join(usages, " ")
+}
+
+// parseArguments matches values against the argument definitions in order.
+// Each argument takes as many values as it can while leaving enough for the
+// minimum of the arguments after it, so n is never below the minimum once
+// the total minimum has been checked.
+func parseArguments(arguments []Argument, values []string) (map[string]interface{}, error) {
+	required := 0
+	for _, a := range arguments {
+		min, _ := a.Counts()
+		required += min
+		if required <= len(values) {
+			continue
+		}
+		if min == 1 {
+			return nil, fmt.Errorf("Required argument %q not set", a.ArgName())
+		}
+		got := len(values) - (required - min)
+		return nil, fmt.Errorf("Argument %q requires at least %d values, got %d", a.ArgName(), min, got)
+	}
+
+	parsed := make(map[string]interface{}, len(arguments))
+	rest := values
+	for i, a := range arguments {
+		_, max := a.Counts()
+
+		reserved := 0
+		for _, next := range arguments[i+1:] {
+			nextMin, _ := next.Counts()
+			reserved += nextMin
+		}
+
+		n := len(rest) - reserved
+		if max >= 0 && n > max {
+			n = max
+		}
+
+		value, err := a.Parse(rest[:n])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for argument %q: %v", a.ArgName(), err)
+		}
+		parsed[a.ArgName()] = value
+		rest = rest[n:]
+	}
+
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("Unexpected arguments %q", strings.Join(rest, " "))
+	}
+	return parsed, nil
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArgumentsParseTypedValues(t *testing.T) {
+	var (
+		name  string
+		count int
+		ratio float64
+		at    *time.Time
+		files []string
+	)
+
+	app := &App{
+		Writer: &bytes.Buffer{},
+		Commands: []*Command{
+			{
+				Name: "run",
+				Arguments: []Argument{
+					&StringArg{Name: "name", Min: 1},
+					&IntArg{Name: "count", Min: 1},
+					&Float64Arg{Name: "ratio"},
+					&TimestampArg{Name: "at", Layout: "2006-01-02"},
+					&StringArg{Name: "files", Max: -1},
+				},
+				Action: func(c *Context) error {
+					name = c.StringArg("name")
+					count = c.IntArg("count")
+					ratio = c.Float64Arg("ratio")
+					at = c.TimestampArg("at")
+					files = c.StringArgs("files")
+					return nil
+				},
+			},
+		},
+	}
+
+	err := app.Run([]string{"app", "run", "job", "3", "0.5", "2019-12-24", "a.txt", "b.txt"})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	if name != "job" || count != 3 || ratio != 0.5 {
+		t.Errorf("expected job 3 0.5, but got: %s %d %v", name, count, ratio)
+	}
+	if at == nil || !at.Equal(time.Date(2019, 12, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected at to be 2019-12-24, but got: %v", at)
+	}
+	if strings.Join(files, " ") != "a.txt b.txt" {
+		t.Errorf("expected files [a.txt b.txt], but got: %v", files)
+	}
+}
+
+func TestParseArguments(t *testing.T) {
+	arguments := []Argument{
+		&StringArg{Name: "src", Min: 1, Max: -1},
+		&StringArg{Name: "dst", Min: 1},
+	}
+
+	for _, tt := range []struct {
+		values  []string
+		src     []string
+		dst     []string
+		wantErr string
+	}{
+		{values: []string{"a", "b", "c"}, src: []string{"a", "b"}, dst: []string{"c"}},
+		{values: []string{"a", "b"}, src: []string{"a"}, dst: []string{"b"}},
+		{values: []string{"a"}, wantErr: `Required argument "dst" not set`},
+	} {
+		parsed, err := parseArguments(arguments, tt.values)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("%v: expected error %q, but got: %v", tt.values, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v: expected no error, but got: %s", tt.values, err)
+		}
+		if got := parsed["src"].([]string); strings.Join(got, " ") != strings.Join(tt.src, " ") {
+			t.Errorf("%v: expected src %v, but got: %v", tt.values, tt.src, got)
+		}
+		if got := parsed["dst"].([]string); strings.Join(got, " ") != strings.Join(tt.dst, " ") {
+			t.Errorf("%v: expected dst %v, but got: %v", tt.values, tt.dst, got)
+		}
+	}
+
+	if _, err := parseArguments([]Argument{&IntArg{Name: "n"}}, []string{"x"}); err == nil {
+		t.Error("expected invalid int error, but got none")
+	}
+	if _, err := parseArguments([]Argument{&IntArg{Name: "n"}}, []string{"1", "2"}); err == nil {
+		t.Error("expected unexpected arguments error, but got none")
+	}
+}
+
+func TestArgumentsUsage(t *testing.T) {
+	output := &bytes.Buffer{}
+	app := &App{
+		Writer: output,
+		Commands: []*Command{
+			{
+				Name: "cp",
+				Arguments: []Argument{
+					&StringArg{Name: "src", Usage: "files to copy", Min: 1, Max: -1},
+					&StringArg{Name: "dst", Min: 1},
+					&IntArg{Name: "mode"},
+				},
+			},
+		},
+	}
+
+	if err := app.Run([]string{"app", "help", "cp"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	for _, want := range []string{"cp src... dst [mode]", "ARGUMENTS:", "src...  files to copy"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("expected help to contain %q, but got:\n%s", want, output.String())
+		}
+	}
+}
@@ -60,7 +60,7 @@ type commandCategory struct {
 }
 
 func (c *commandCategory) Name() string {
-	return c.Name()
+	return c.name
 }
 
 func (c *commandCategory) VisibleCommands() []*Command {
@@ -0,0 +1,125 @@
+// Package clitest runs cli applications in-process for tests, capturing
+// their output and exit code.
+package clitest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gy-kim/golang-daily-practice/2019/12-Dec/16-31/urfave/cli"
+)
+
+// Update makes AssertGolden write the golden files instead of comparing
+// them. It is enabled by setting the CLITEST_UPDATE environment variable.
+var Update = os.Getenv("CLITEST_UPDATE") != ""
+
+// Case describes a single run of an App.
+type Case struct {
+	// Args are the command line arguments, without the program name
+	Args []string
+
+	// Env holds the environment variables set during the run
+	Env map[string]string
+
+	// Stdin is read through App.Reader
+	Stdin string
+}
+
+// Result is the outcome of a run.
+type Result struct {
+	Stdout string
+	Stderr string
+
+	// ExitCode is the code passed to App.OsExiter, or 1 when the run
+	// returned an error without exiting and 0 otherwise
+	ExitCode int
+
+	// Err is the error returned by App.Run
+	Err error
+}
+
+// Run runs app with the arguments, environment and input of c. The
+// environment is process wide, so runs must not happen in parallel.
+// HelpName defaults to the app name rather than the test binary name so that
+// help output is stable.
+func Run(app *cli.App, c Case) *Result {
+	if app.HelpName == "" {
+		app.HelpName = app.Name
+	}
+
+	var stdout, stderr bytes.Buffer
+	app.Reader = strings.NewReader(c.Stdin)
+	app.Writer = &stdout
+	app.ErrWriter = &stderr
+
+	exited := false
+	res := &Result{}
+	app.OsExiter = func(code int) {
+		if !exited {
+			exited = true
+			res.ExitCode = code
+		}
+	}
+
+	restore := setenv(c.Env)
+	defer restore()
+
+	res.Err = app.Run(append([]string{app.Name}, c.Args...))
+	if !exited && res.Err != nil {
+		res.ExitCode = 1
+	}
+	res.Stdout = stdout.String()
+	res.Stderr = stderr.String()
+	return res
+}
+
+// AssertGolden compares got with the content of the golden file at path,
+// or writes got to it when Update is set.
+func AssertGolden(t testing.TB, path, got string) {
+	t.Helper()
+
+	if Update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (set CLITEST_UPDATE=1 to create it): %s", err)
+	}
+	if got != string(want) {
+		t.Errorf("output does not match golden file %s\n--- expected\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+func setenv(env map[string]string) func() {
+	type previous struct {
+		value string
+		ok    bool
+	}
+	saved := make(map[string]previous, len(env))
+	for key, value := range env {
+		old, ok := os.LookupEnv(key)
+		saved[key] = previous{value: old, ok: ok}
+		_ = os.Setenv(key, value)
+	}
+
+	return func() {
+		for key, p := range saved {
+			if p.ok {
+				_ = os.Setenv(key, p.value)
+			} else {
+				_ = os.Unsetenv(key)
+			}
+		}
+	}
+}
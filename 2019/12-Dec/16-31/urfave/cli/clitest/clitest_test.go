@@ -0,0 +1,78 @@
+package clitest
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gy-kim/golang-daily-practice/2019/12-Dec/16-31/urfave/cli"
+)
+
+func newTestApp() *cli.App {
+	return &cli.App{
+		Name:  "greet",
+		Usage: "say hello",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "name", Value: "world", EnvVars: []string{"GREET_NAME"}, Usage: "who to greet"},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "echo",
+				Usage: "print stdin",
+				Action: func(c *cli.Context) error {
+					in, err := ioutil.ReadAll(c.App.Reader)
+					if err != nil {
+						return err
+					}
+					_, err = c.App.Writer.Write(in)
+					return err
+				},
+			},
+			{
+				Name: "fail",
+				Action: func(c *cli.Context) error {
+					return cli.Exit("failed", 3)
+				},
+			},
+		},
+		Action: func(c *cli.Context) error {
+			_, err := c.App.Writer.Write([]byte("hello " + c.String("name") + "\n"))
+			return err
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		c      Case
+		stdout string
+		stderr string
+		code   int
+	}{
+		{name: "default", stdout: "hello world\n"},
+		{name: "flag", c: Case{Args: []string{"--name", "gopher"}}, stdout: "hello gopher\n"},
+		{name: "env", c: Case{Env: map[string]string{"GREET_NAME": "env"}}, stdout: "hello env\n"},
+		{name: "stdin", c: Case{Args: []string{"echo"}, Stdin: "from stdin"}, stdout: "from stdin"},
+		{name: "exit code", c: Case{Args: []string{"fail"}}, stderr: "failed\n", code: 3},
+	} {
+		res := Run(newTestApp(), tt.c)
+		if res.Stdout != tt.stdout || res.Stderr != tt.stderr || res.ExitCode != tt.code {
+			t.Errorf("%s: expected %q, %q and code %d, but got: %q, %q and code %d",
+				tt.name, tt.stdout, tt.stderr, tt.code, res.Stdout, res.Stderr, res.ExitCode)
+		}
+	}
+}
+
+func TestRunUsageErrorExitsWithOne(t *testing.T) {
+	res := Run(newTestApp(), Case{Args: []string{"--nope"}})
+	if res.Err == nil || res.ExitCode != 1 || !strings.Contains(res.Stdout, "Incorrect Usage.") {
+		t.Errorf("expected usage error with code 1, but got: %+v", res)
+	}
+}
+
+func TestHelpGolden(t *testing.T) {
+	res := Run(newTestApp(), Case{Args: []string{"--help"}})
+	AssertGolden(t, filepath.Join("testdata", "help.golden"), res.Stdout)
+}
@@ -1,5 +1,12 @@
 package cli
 
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // Command is a subcommand for a cli.App.
 type Command struct {
 	// The name of the command
@@ -9,6 +16,7 @@ type Command struct {
 	Aliases []string
 
 	Usage                  string
+	UsageText              string
 	Description            string
 	ArgsUsage              string
 	Category               string
@@ -19,11 +27,298 @@ type Command struct {
 	OnUsageError           OnUsageErrorFunc
 	Subcommands            []*Command
 	Flags                  []Flag
+	Arguments              []Argument
+	FlagGroups             []FlagGroup
+	FlagDependencies       []FlagDependency
 	SkipFlagParsing        bool
 	HideHelp               bool
 	Hidden                 bool
 	UseShortOptionHandling bool
+	AllowInterspersed      bool
 	HelpName               string
 	commandNamePath        []string
 	CustomHelpTemplate     string
+
+	// bindings copy parsed values into the struct of CommandFromStruct
+	bindings []func(*Context)
+}
+
+// FullName returns the full name of the command.
+// For subcommands this ensures that parent commands are part of the command path
+func (c *Command) FullName() string {
+	if c.commandNamePath == nil {
+		return c.Name
+	}
+	return strings.Join(c.commandNamePath, " ")
+}
+
+// Run invokes the command given the context, parses ctx.Args() to generate command-specific flags
+func (c *Command) Run(ctx *Context) (err error) {
+	if len(c.Subcommands) > 0 {
+		return c.startApp(ctx)
+	}
+
+	if !c.HideHelp && HelpFlag != nil {
+		// append help to flags
+		c.appendFlag(HelpFlag)
+	}
+
+	if ctx.App.UseShortOptionHandling {
+		c.UseShortOptionHandling = true
+	}
+
+	if ctx.App.AllowInterspersed {
+		c.AllowInterspersed = true
+	}
+
+	set, err := c.parseFlags(ctx.Args(), ctx.shellComplete)
+
+	context := NewContext(ctx.App, set, ctx)
+	context.Command = c
+	if checkCommandCompletions(context, c.Name) {
+		return nil
+	}
+
+	if err != nil {
+		if c.OnUsageError != nil {
+			err = c.OnUsageError(context, err, false)
+			context.App.handleExitCoder(context, err)
+			return err
+		}
+		if context.App.reportUsageError(err) {
+			return err
+		}
+		_, _ = fmt.Fprintln(context.App.Writer, "Incorrect Usage:", err.Error())
+		_, _ = fmt.Fprintln(context.App.Writer)
+		if suggestion := context.App.suggestFlagMessage(c.Flags, err); suggestion != "" {
+			_, _ = fmt.Fprintf(context.App.Writer, "%s\n\n", suggestion)
+		}
+		_ = ShowCommandHelp(context, c.Name)
+		return err
+	}
+
+	if checkCommandHelp(context, c.Name) {
+		return nil
+	}
+
+	context.App.promptForMissingFlags(c.Flags, context)
+
+	cerr := checkFlagConstraints(c.Flags, c.FlagGroups, c.FlagDependencies, context)
+	if cerr != nil {
+		if !context.App.reportUsageError(cerr) {
+			_ = ShowCommandHelp(context, c.Name)
+		}
+		return cerr
+	}
+
+	if len(c.Arguments) > 0 {
+		context.arguments, err = parseArguments(c.Arguments, context.Args().Slice())
+		if err != nil {
+			if c.OnUsageError != nil {
+				err = c.OnUsageError(context, err, false)
+				context.App.handleExitCoder(context, err)
+				return err
+			}
+			if context.App.reportUsageError(err) {
+				return err
+			}
+			_, _ = fmt.Fprintln(context.App.Writer, "Incorrect Usage:", err.Error())
+			_, _ = fmt.Fprintln(context.App.Writer)
+			_ = ShowCommandHelp(context, c.Name)
+			return err
+		}
+	}
+
+	if c.After != nil {
+		defer func() {
+			afterErr := c.After(context)
+			if afterErr != nil {
+				context.App.handleExitCoder(context, err)
+				if err != nil {
+					err = newMultiError(err, afterErr)
+				} else {
+					err = afterErr
+				}
+			}
+		}()
+	}
+
+	if c.Before != nil {
+		err = c.Before(context)
+		if err != nil {
+			_ = ShowCommandHelp(context, c.Name)
+			context.App.handleExitCoder(context, err)
+			return err
+		}
+	}
+
+	// bind after Before, which may set flags from other sources
+	for _, bind := range c.bindings {
+		bind(context)
+	}
+
+	if c.Action == nil {
+		c.Action = helpSubcommand.Action
+	}
+
+	err = c.Action(context)
+
+	if err != nil {
+		context.App.handleExitCoder(context, err)
+	}
+	return err
+}
+
+func (c *Command) newFlagSet() (*flag.FlagSet, error) {
+	return flagSet(c.Name, c.Flags)
+}
+
+func (c *Command) useShortOptionHandling() bool {
+	return c.UseShortOptionHandling
+}
+
+func (c *Command) allowInterspersed() bool {
+	return c.AllowInterspersed
+}
+
+func (c *Command) isSubcommand(name string) bool {
+	for _, sub := range c.Subcommands {
+		if sub.HasName(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Command) parseFlags(args Args, shellComplete bool) (*flag.FlagSet, error) {
+	set, err := c.newFlagSet()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.SkipFlagParsing {
+		return set, set.Parse(append([]string{"--"}, args.Tail()...))
+	}
+
+	err = parseIter(set, c, args.Tail(), shellComplete)
+	if err != nil {
+		return nil, err
+	}
+
+	err = normalizeFlags(c.Flags, set)
+	if err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+// Names returns the names including short names and aliases.
+func (c *Command) Names() []string {
+	return append([]string{c.Name}, c.Aliases...)
+}
+
+// HasName returns true if Command.Name matches given name
+func (c *Command) HasName(name string) bool {
+	for _, n := range c.Names() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Command) startApp(ctx *Context) error {
+	app := &App{
+		Metadata: ctx.App.Metadata,
+		Name:     fmt.Sprintf("%s %s", ctx.App.Name, c.Name),
+	}
+
+	if c.HelpName == "" {
+		app.HelpName = app.Name
+	} else {
+		app.HelpName = c.HelpName
+	}
+
+	app.Usage = c.Usage
+	app.Description = c.Description
+	app.ArgsUsage = c.ArgsUsage
+
+	// set CommandNotFound
+	app.CommandNotFound = ctx.App.CommandNotFound
+	app.CustomAppHelpTemplate = c.CustomHelpTemplate
+
+	// set the flags and commands
+	app.Commands = c.Subcommands
+	app.Flags = c.Flags
+	app.FlagGroups = c.FlagGroups
+	app.FlagDependencies = c.FlagDependencies
+	app.HideHelp = c.HideHelp
+
+	app.Version = ctx.App.Version
+	app.HideVersion = ctx.App.HideVersion
+	app.Compiled = ctx.App.Compiled
+	app.Reader = ctx.App.Reader
+	app.Writer = ctx.App.Writer
+	app.ErrWriter = ctx.App.ErrWriter
+	app.ExitErrHandler = ctx.App.ExitErrHandler
+	app.ErrorFormat = ctx.App.ErrorFormat
+	app.OsExiter = ctx.App.OsExiter
+	app.UseShortOptionHandling = ctx.App.UseShortOptionHandling
+	app.AllowInterspersed = ctx.App.AllowInterspersed || c.AllowInterspersed
+	app.PromptForMissing = ctx.App.PromptForMissing
+	app.Suggest = ctx.App.Suggest
+	app.SuggestCommandFunc = ctx.App.SuggestCommandFunc
+	app.SuggestFlagFunc = ctx.App.SuggestFlagFunc
+
+	app.categories = newCommandCategories()
+	for _, command := range c.Subcommands {
+		app.categories.AddCommand(command.Category, command)
+	}
+
+	sort.Sort(app.categories.(*commandCategories))
+
+	// bash completion is enabled on the root app only, so the completion
+	// command is not repeated for every command with subcommands
+	if c.BashComplete != nil {
+		app.BashComplete = c.BashComplete
+	}
+
+	// set the actions
+	app.Before = c.Before
+	app.After = c.After
+	if c.Action != nil {
+		app.Action = c.Action
+	} else {
+		app.Action = helpSubcommand.Action
+	}
+	app.OnUsageError = c.OnUsageError
+	app.bindings = c.bindings
+
+	for index, cc := range app.Commands {
+		app.Commands[index].commandNamePath = []string{c.Name, cc.Name}
+	}
+
+	return app.RunAsSubcommand(ctx)
+}
+
+// VisibleFlags returns a slice of the Flags with Hidden=false
+func (c *Command) VisibleFlags() []Flag {
+	return visibleFlags(c.Flags)
+}
+
+func (c *Command) appendFlag(fl Flag) {
+	if !hasFlag(c.Flags, fl) {
+		c.Flags = append(c.Flags, fl)
+	}
+}
+
+func hasCommand(commands []*Command, command *Command) bool {
+	for _, existing := range commands {
+		if command == existing {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+	genericType  = reflect.TypeOf((*Generic)(nil)).Elem()
+)
+
+// CommandFromStruct returns a Command whose flags are generated from the
+// tagged fields of the struct opts points to. The parsed values are bound
+// back into the struct after the Before func runs, so values it sets, like
+// altsrc does, are bound as well, and before the Action and any subcommand
+// run. Name, Usage and Action are left for the caller to set.
+//
+// Only fields with a cli tag become flags:
+//
+//	type options struct {
+//		Config  string        `cli:"config,c" usage:"load configuration from FILE" env:"APP_CONFIG"`
+//		Port    int           `cli:"port" default:"8080"`
+//		Tags    []string      `cli:"tag" required:"true"`
+//		Timeout time.Duration `cli:"timeout" default:"5s"`
+//		Since   time.Time     `cli:"since" layout:"2006-01-02"`
+//	}
+//
+// The cli tag holds the flag name followed by its aliases, usage is the
+// usage text, env a comma separated list of environment variables and
+// required marks the flag as required. default is parsed like a command
+// line value, slices taking comma separated values, and otherwise the
+// current value of the field is the default. layout is the time.Parse
+// layout of time.Time fields, defaulting to time.RFC3339.
+//
+// Supported field types are string, bool, int, int64, uint, uint64,
+// float64, time.Duration, time.Time, slices of string, int, int64 and
+// float64, and any type whose pointer implements Generic. Nested structs
+// without a cli tag are walked as well.
+func CommandFromStruct(opts interface{}) (*Command, error) {
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a pointer to a struct, but got %T", opts)
+	}
+
+	cmd := &Command{}
+	if err := cmd.appendStructFlags(v.Elem()); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func (c *Command) appendStructFlags(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := v.Field(i)
+		if sf.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		tag, ok := sf.Tag.Lookup("cli")
+		if !ok {
+			if field.Kind() == reflect.Struct && sf.Type != timeType && !reflect.PtrTo(sf.Type).Implements(genericType) {
+				if err := c.appendStructFlags(field); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if tag == "-" {
+			continue
+		}
+
+		f, bind, err := structFieldFlag(sf, field, tag)
+		if err != nil {
+			return err
+		}
+		c.Flags = append(c.Flags, f)
+		if bind != nil {
+			c.bindings = append(c.bindings, bind)
+		}
+	}
+	return nil
+}
+
+// structFieldFlag returns the flag generated for a struct field, and the
+// func binding its parsed value into the field for flags which have no
+// Destination.
+func structFieldFlag(sf reflect.StructField, field reflect.Value, tag string) (Flag, func(*Context), error) {
+	names := strings.Split(tag, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	name, aliases := names[0], names[1:]
+	if name == "" {
+		return nil, nil, fmt.Errorf("field %s has no flag name", sf.Name)
+	}
+
+	usage := sf.Tag.Get("usage")
+	var envVars []string
+	if env := sf.Tag.Get("env"); env != "" {
+		envVars = strings.Split(env, ",")
+	}
+	required := false
+	if req, ok := sf.Tag.Lookup("required"); ok {
+		required = req != "false"
+	}
+	layout := sf.Tag.Get("layout")
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	if def, ok := sf.Tag.Lookup("default"); ok {
+		if err := setFieldFromString(field, def, layout); err != nil {
+			return nil, nil, fmt.Errorf("invalid default %q for field %s: %s", def, sf.Name, err)
+		}
+	}
+
+	addr := field.Addr().Interface()
+	if generic, ok := addr.(Generic); ok {
+		return &GenericFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: generic}, nil, nil
+	}
+
+	switch sf.Type {
+	case durationType:
+		return &DurationFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: field.Interface().(time.Duration), Destination: addr.(*time.Duration)}, nil, nil
+	case timeType:
+		f := &TimestampFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Layout: layout}
+		if t := field.Interface().(time.Time); !t.IsZero() {
+			f.Value = NewTimestamp(t)
+		}
+		bind := func(c *Context) {
+			if t := c.Timestamp(name); t != nil {
+				field.Set(reflect.ValueOf(*t))
+			}
+		}
+		return f, bind, nil
+	}
+
+	switch p := addr.(type) {
+	case *string:
+		return &StringFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: *p, Destination: p}, nil, nil
+	case *bool:
+		return &BoolFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: *p, Destination: p}, nil, nil
+	case *int:
+		return &IntFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: *p, Destination: p}, nil, nil
+	case *int64:
+		return &Int64Flag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: *p, Destination: p}, nil, nil
+	case *uint:
+		return &UintFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: *p, Destination: p}, nil, nil
+	case *uint64:
+		return &Uint64Flag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: *p, Destination: p}, nil, nil
+	case *float64:
+		return &Float64Flag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: *p, Destination: p}, nil, nil
+	case *[]string:
+		bind := func(c *Context) { *p = c.StringSlice(name) }
+		return &StringSliceFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: NewStringSlice(*p...)}, bind, nil
+	case *[]int:
+		bind := func(c *Context) { *p = c.IntSlice(name) }
+		return &IntSliceFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: NewIntSlice(*p...)}, bind, nil
+	case *[]int64:
+		bind := func(c *Context) { *p = c.Int64Slice(name) }
+		return &Int64SliceFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: NewInt64Slice(*p...)}, bind, nil
+	case *[]float64:
+		bind := func(c *Context) { *p = c.Float64Slice(name) }
+		return &Float64SliceFlag{Name: name, Aliases: aliases, Usage: usage, EnvVars: envVars, Required: required, Value: NewFloat64Slice(*p...)}, bind, nil
+	}
+
+	return nil, nil, fmt.Errorf("unsupported type %s for field %s", sf.Type, sf.Name)
+}
+
+// setFieldFromString parses s into field the way a command line value of
+// the matching flag type would be parsed.
+func setFieldFromString(field reflect.Value, s, layout string) error {
+	if generic, ok := field.Addr().Interface().(Generic); ok {
+		return generic.Set(s)
+	}
+
+	switch field.Type() {
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	case timeType:
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if field.Kind() == reflect.Slice {
+		var parts []string
+		if s != "" {
+			parts = strings.Split(s, ",")
+		}
+		slice := reflect.MakeSlice(field.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setFieldFromString(slice.Index(i), strings.TrimSpace(part), layout); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		i, err := strconv.ParseInt(s, 0, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 0, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
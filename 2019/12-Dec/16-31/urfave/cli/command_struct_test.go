@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type level int
+
+func (l *level) Set(value string) error {
+	switch value {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", value)
+	}
+	return nil
+}
+
+func (l *level) String() string {
+	return fmt.Sprintf("%d", int(*l))
+}
+
+type serverOptions struct {
+	Host    string        `cli:"host,H" usage:"host to bind" env:"STRUCT_TEST_HOST"`
+	Port    int           `cli:"port" default:"8080"`
+	Debug   bool          `cli:"debug"`
+	Ratio   float64       `cli:"ratio" default:"0.5"`
+	Timeout time.Duration `cli:"timeout" default:"5s"`
+	Since   time.Time     `cli:"since" layout:"2006-01-02"`
+	Tags    []string      `cli:"tag" required:"true"`
+	Ports   []int         `cli:"extra-port" default:"1,2"`
+	Level   level         `cli:"level"`
+	TLS     struct {
+		Cert string `cli:"tls-cert"`
+	}
+	Ignored string
+}
+
+func TestCommandFromStruct(t *testing.T) {
+	var opts serverOptions
+	cmd, err := CommandFromStruct(&opts)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	cmd.Name = "serve"
+
+	var seen serverOptions
+	cmd.Action = func(c *Context) error {
+		seen = opts
+		return nil
+	}
+
+	if len(cmd.Flags) != 10 {
+		t.Fatalf("expected 10 flags, but got: %d", len(cmd.Flags))
+	}
+
+	app := &App{Writer: &bytes.Buffer{}, Commands: []*Command{cmd}}
+	err = app.Run([]string{"app", "serve",
+		"-H", "localhost", "--debug", "--timeout", "1m", "--since", "2019-12-24",
+		"--tag", "a", "--tag", "b", "--level", "high", "--tls-cert", "cert.pem"})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+
+	if seen.Host != "localhost" || seen.Port != 8080 || !seen.Debug || seen.Ratio != 0.5 || seen.Timeout != time.Minute {
+		t.Errorf("expected scalar values to be bound, but got: %+v", seen)
+	}
+	if !seen.Since.Equal(time.Date(2019, 12, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected since to be 2019-12-24, but got: %v", seen.Since)
+	}
+	if strings.Join(seen.Tags, ",") != "a,b" || len(seen.Ports) != 2 || seen.Ports[1] != 2 {
+		t.Errorf("expected slices to be bound, but got: %v and %v", seen.Tags, seen.Ports)
+	}
+	if seen.Level != 2 || seen.TLS.Cert != "cert.pem" {
+		t.Errorf("expected generic and nested values to be bound, but got: %v and %q", seen.Level, seen.TLS.Cert)
+	}
+}
+
+func TestCommandFromStructRequiredFlag(t *testing.T) {
+	var opts serverOptions
+	cmd, err := CommandFromStruct(&opts)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	cmd.Name = "serve"
+
+	err = (&App{Writer: &bytes.Buffer{}, Commands: []*Command{cmd}}).Run([]string{"app", "serve"})
+	if err == nil || !strings.Contains(err.Error(), `"tag"`) {
+		t.Errorf("expected required tag flag error, but got: %v", err)
+	}
+}
+
+func TestCommandFromStructErrors(t *testing.T) {
+	for _, opts := range []interface{}{
+		serverOptions{},
+		&struct {
+			C chan int `cli:"c"`
+		}{},
+		&struct {
+			N int `cli:"n" default:"x"`
+		}{},
+	} {
+		if _, err := CommandFromStruct(opts); err == nil {
+			t.Errorf("%T: expected an error, but got none", opts)
+		}
+	}
+}
+
+func TestCommandFromStructWithSubcommands(t *testing.T) {
+	var opts serverOptions
+	cmd, err := CommandFromStruct(&opts)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	cmd.Name = "serve"
+
+	var seen serverOptions
+	cmd.Subcommands = []*Command{{
+		Name: "status",
+		Action: func(c *Context) error {
+			seen = opts
+			return nil
+		},
+	}}
+
+	app := &App{Writer: &bytes.Buffer{}, Commands: []*Command{cmd}}
+	err = app.Run([]string{"app", "serve", "--since", "2019-12-24", "--tag", "a", "--tag", "b", "status"})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+
+	if !seen.Since.Equal(time.Date(2019, 12, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected since to be 2019-12-24, but got: %v", seen.Since)
+	}
+	if strings.Join(seen.Tags, ",") != "a,b" || len(seen.Ports) != 2 {
+		t.Errorf("expected slices to be bound, but got: %v and %v", seen.Tags, seen.Ports)
+	}
+}
+
+func TestCommandFromStructBindsValuesSetInBefore(t *testing.T) {
+	var opts serverOptions
+	cmd, err := CommandFromStruct(&opts)
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	cmd.Name = "serve"
+	cmd.Before = func(c *Context) error {
+		_ = c.Set("extra-port", "9")
+		return c.Set("since", "2019-12-31")
+	}
+
+	var seen serverOptions
+	cmd.Action = func(c *Context) error {
+		seen = opts
+		return nil
+	}
+
+	app := &App{Writer: &bytes.Buffer{}, Commands: []*Command{cmd}}
+	if err := app.Run([]string{"app", "serve", "--tag", "a"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	if !seen.Since.Equal(time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected since set in Before to be bound, but got: %v", seen.Since)
+	}
+	if len(seen.Ports) != 1 || seen.Ports[0] != 9 {
+		t.Errorf("expected ports set in Before to be bound, but got: %v", seen.Ports)
+	}
+}
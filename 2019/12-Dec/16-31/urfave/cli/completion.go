@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+var completionCommand = &Command{
+	Name:      "completion",
+	Usage:     "Output shell completion script for bash, zsh or fish",
+	ArgsUsage: "bash|zsh|fish",
+	Action: func(c *Context) error {
+		var (
+			script string
+			err    error
+		)
+
+		switch shell := c.Args().First(); shell {
+		case "bash":
+			script, err = c.App.ToBashCompletion()
+		case "zsh":
+			script, err = c.App.ToZshCompletion()
+		case "fish":
+			script, err = c.App.ToFishCompletion()
+		case "":
+			return Exit("shell name is required: bash, zsh or fish", 1)
+		default:
+			return Exit(fmt.Sprintf("unsupported shell %q: use bash, zsh or fish", shell), 1)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprint(c.App.Writer, script)
+		return err
+	},
+}
+
+type shellCompletionTemplate struct {
+	App            *App
+	FuncName       string
+	CompletionFlag string
+	Completions    []string
+	AllCommands    []string
+}
+
+// ToBashCompletion creates a bash completion script for the `*App`.
+// The script relies on the hidden completion flag, so the app needs
+// EnableBashCompletion set for it to produce candidates.
+func (a *App) ToBashCompletion() (string, error) {
+	var w bytes.Buffer
+	if err := a.writeCompletionTemplate(&w, BashCompletionTemplate, nil); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+// ToZshCompletion creates a zsh completion script for the `*App`.
+// The script relies on the hidden completion flag, so the app needs
+// EnableBashCompletion set for it to produce candidates.
+func (a *App) ToZshCompletion() (string, error) {
+	var w bytes.Buffer
+	if err := a.writeCompletionTemplate(&w, ZshCompletionTemplate, nil); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+func (a *App) writeCompletionTemplate(w io.Writer, templ string, data *shellCompletionTemplate) error {
+	const name = "cli"
+	t, err := template.New(name).Parse(templ)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		data = &shellCompletionTemplate{}
+	}
+	data.App = a
+	data.FuncName = completionFuncName(a.Name)
+	data.CompletionFlag = strings.TrimSpace(BashCompletionFlag.Names()[0])
+
+	return t.ExecuteTemplate(w, name, data)
+}
+
+// completionFuncName turns the app name into something usable as part of a
+// shell function name.
+func completionFuncName(name string) string {
+	return nonIdentifierChars.ReplaceAllString(name, "_")
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func newCompletionTestApp(output *bytes.Buffer) *App {
+	return &App{
+		Name:                 "greet",
+		Writer:               output,
+		EnableBashCompletion: true,
+		Flags:                []Flag{&StringSliceFlag{Name: "config", TakesFile: true, Usage: "config file"}},
+		Commands: []*Command{
+			{
+				Name:    "hello",
+				Aliases: []string{"hi"},
+				Usage:   "say hello",
+				Flags:   []Flag{&BoolFlag{Name: "loud"}},
+			},
+			{
+				Name: "remote",
+				Subcommands: []*Command{
+					{Name: "add"},
+					{Name: "rm", Hidden: true},
+				},
+			},
+		},
+	}
+}
+
+func TestShellCompletionFlagPrintsCommands(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want []string
+	}{
+		{args: []string{"greet", "hello", "--l", "--generate-bash-completion"}, want: []string{"--loud"}},
+		{args: []string{"greet", "hello", "--loud", "--l", "--generate-bash-completion"}, want: nil},
+		{args: []string{"greet", "--generate-bash-completion"}, want: []string{"hello", "hi", "remote", "help", "h", "completion"}},
+		{args: []string{"greet", "remote", "--generate-bash-completion"}, want: []string{"add", "help", "h"}},
+	} {
+		output := &bytes.Buffer{}
+		if err := newCompletionTestApp(output).Run(tt.args); err != nil {
+			t.Fatalf("expected no error, but got: %s", err)
+		}
+		got := strings.Fields(output.String())
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("%v: expected completions %v, but got: %v", tt.args, tt.want, got)
+		}
+	}
+}
+
+func TestCompletionCommandPrintsScripts(t *testing.T) {
+	for shell, want := range map[string][]string{
+		"bash": {"complete -o bashdefault", "_greet_bash_autocomplete", "--generate-bash-completion"},
+		"zsh":  {"#compdef greet", "compdef _greet_zsh_autocomplete greet"},
+		"fish": {
+			"complete -c greet -n '__fish_greet_no_subcommand' -l config -r -d 'config file'",
+			"complete -r -c greet -n '__fish_greet_no_subcommand' -a 'hello hi' -d 'say hello'",
+			"complete -c greet -n '__fish_seen_subcommand_from hello hi' -f -l loud",
+			"complete -r -c greet -n '__fish_seen_subcommand_from remote' -a 'add'",
+		},
+	} {
+		output := &bytes.Buffer{}
+		if err := newCompletionTestApp(output).Run([]string{"greet", "completion", shell}); err != nil {
+			t.Fatalf("%s: expected no error, but got: %s", shell, err)
+		}
+		for _, w := range want {
+			if !strings.Contains(output.String(), w) {
+				t.Errorf("%s: expected script to contain %q, but got:\n%s", shell, w, output.String())
+			}
+		}
+	}
+}
+
+func TestCompletionCommandRejectsUnknownShell(t *testing.T) {
+	app := newCompletionTestApp(&bytes.Buffer{})
+	app.ExitErrHandler = func(c *Context, err error) {}
+
+	err := app.Run([]string{"greet", "completion", "tcsh"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported shell") {
+		t.Errorf("expected unsupported shell error, but got: %v", err)
+	}
+}
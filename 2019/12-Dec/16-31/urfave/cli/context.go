@@ -16,8 +16,12 @@ type Context struct {
 	Command       *Command
 	shellComplete bool
 	setFlags      map[string]bool
+	arguments     map[string]interface{}
 	flagSet       *flag.FlagSet
 	parentContext *Context
+
+	// runArgs are the arguments the app was run with
+	runArgs []string
 }
 
 // NewContext creates a new context. For us in when invoking an App or Command action.
@@ -26,6 +30,7 @@ func NewContext(app *App, set *flag.FlagSet, parentCtx *Context) *Context {
 	if parentCtx != nil {
 		c.Context = parentCtx.Context
 		c.shellComplete = parentCtx.shellComplete
+		c.runArgs = parentCtx.runArgs
 	}
 
 	c.Command = &Command{}
@@ -83,6 +88,9 @@ func (c *Context) LocalFlagNames() []string {
 func (c *Context) FlagNames() []string {
 	var names []string
 	for _, ctx := range c.Lineage() {
+		if ctx.flagSet == nil {
+			continue
+		}
 		ctx.flagSet.Visit(makeFlagNameVisitor(&names))
 	}
 	return names
@@ -143,6 +151,9 @@ func lookupFlag(name string, ctx *Context) Flag {
 
 func lookupFlagSet(name string, ctx *Context) *flag.FlagSet {
 	for _, c := range ctx.Lineage() {
+		if c.flagSet == nil {
+			continue
+		}
 		if f := c.flagSet.Lookup(name); f != nil {
 			return c.flagSet
 		}
@@ -153,7 +164,7 @@ func lookupFlagSet(name string, ctx *Context) *flag.FlagSet {
 func copyFlag(name string, ff *flag.Flag, set *flag.FlagSet) {
 	switch ff.Value.(type) {
 	case Serializer:
-		_ = set.Set(name, ff.Value.(Serializer).Serializer())
+		_ = set.Set(name, ff.Value.(Serializer).Serialize())
 	default:
 		_ = set.Set(name, ff.Value.String())
 	}
@@ -240,7 +251,7 @@ func checkRequiredFlags(flags []Flag, context *Context) requiredFlagsErr {
 			var flagName string
 
 			for _, key := range f.Names() {
-				if len(key) > 1 {
+				if len(key) > 1 || flagName == "" {
 					flagName = key
 				}
 				if context.IsSet(strings.TrimSpace(key)) {
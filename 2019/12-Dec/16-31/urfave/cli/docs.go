@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// ToMarkdown creates a markdown string for the `*App`
+// The function errors if either parsing or writing of the string fails.
+func (a *App) ToMarkdown() (string, error) {
+	var w bytes.Buffer
+	if err := a.writeDocTemplate(&w, MarkdownDocTemplate); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+// ToMan creates a man page string for the `*App`
+// The function errors if either parsing or writing of the string fails.
+func (a *App) ToMan() (string, error) {
+	var w bytes.Buffer
+	if err := a.writeDocTemplate(&w, ManDocTemplate); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+type cliTemplate struct {
+	App          *App
+	Categories   []docCategory
+	GlobalFlags  []docFlag
+	SynopsisArgs []string
+}
+
+type docCategory struct {
+	Name     string
+	Commands []docCommand
+}
+
+type docCommand struct {
+	Level       int
+	FullName    string
+	Names       []string
+	Usage       string
+	Description string
+	ArgsUsage   string
+	Flags       []docFlag
+}
+
+type docFlag struct {
+	Names      []string
+	TakesValue bool
+	Usage      string
+	Value      string
+}
+
+var docFuncMap = template.FuncMap{
+	"join":    strings.Join,
+	"repeat":  strings.Repeat,
+	"add":     func(a, b int) int { return a + b },
+	"roff":    roffEscape,
+	"roffOpt": roffOption,
+	"upper":   strings.ToUpper,
+}
+
+func (a *App) writeDocTemplate(w io.Writer, templ string) error {
+	const name = "cli"
+	t, err := template.New(name).Funcs(docFuncMap).Parse(templ)
+	if err != nil {
+		return err
+	}
+
+	return t.ExecuteTemplate(w, name, &cliTemplate{
+		App:          a,
+		Categories:   prepareCategories(a.Commands),
+		GlobalFlags:  prepareFlags(a.VisibleFlags()),
+		SynopsisArgs: prepareArgsSynopsis(a.VisibleFlags()),
+	})
+}
+
+// prepareCategories groups the visible top level commands by category,
+// sorted the same way the help output sorts them. Commands without a
+// category come first.
+func prepareCategories(commands []*Command) []docCategory {
+	categories := newCommandCategories()
+	for _, command := range commands {
+		categories.AddCommand(command.Category, command)
+	}
+	sort.Sort(categories.(*commandCategories))
+
+	var ret []docCategory
+	for _, category := range categories.Categories() {
+		visible := category.VisibleCommands()
+		if len(visible) == 0 {
+			continue
+		}
+		ret = append(ret, docCategory{
+			Name:     category.Name(),
+			Commands: prepareCommands(visible, nil, 0),
+		})
+	}
+	return ret
+}
+
+func prepareCommands(commands []*Command, parents []string, level int) []docCommand {
+	var coms []docCommand
+	for _, command := range commands {
+		if command.Hidden {
+			continue
+		}
+
+		path := append(append([]string{}, parents...), command.Name)
+		coms = append(coms, docCommand{
+			Level:       level,
+			FullName:    strings.Join(path, " "),
+			Names:       command.Names(),
+			Usage:       command.Usage,
+			Description: command.Description,
+			ArgsUsage:   commandArgsUsage(command),
+			Flags:       prepareFlags(command.VisibleFlags()),
+		})
+
+		// recursively iterate subcommands
+		if len(command.Subcommands) > 0 {
+			coms = append(coms, prepareCommands(command.Subcommands, path, level+1)...)
+		}
+	}
+
+	return coms
+}
+
+func commandArgsUsage(command *Command) string {
+	if command.ArgsUsage == "" && len(command.Arguments) > 0 {
+		return argumentsUsage(command.Arguments)
+	}
+	return command.ArgsUsage
+}
+
+func prepareFlags(flags []Flag) []docFlag {
+	var ret []docFlag
+	for _, f := range flags {
+		flag, ok := f.(DocGenerationFlag)
+		if !ok {
+			continue
+		}
+
+		_, usage := unquoteUsage(flag.GetUsage())
+		ret = append(ret, docFlag{
+			Names:      prefixedFlagNames(flag),
+			TakesValue: flag.TakesValue(),
+			Usage:      usage,
+			Value:      flagDefault(flag),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return lexicographicLess(ret[i].Names[0], ret[j].Names[0])
+	})
+	return ret
+}
+
+func prepareArgsSynopsis(flags []Flag) []string {
+	args := []string{}
+	for _, f := range prepareFlags(flags) {
+		arg := fmt.Sprintf("[%s]", strings.Join(f.Names, "|"))
+		if f.TakesValue {
+			arg += "=[value]"
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+func prefixedFlagNames(flag Flag) []string {
+	var names []string
+	for _, name := range flag.Names() {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		names = append(names, prefixFor(name)+name)
+	}
+	return names
+}
+
+// flagDefault returns the default shown in the documentation, preferring
+// DefaultText over the value of the flag.
+func flagDefault(flag DocGenerationFlag) string {
+	if text := flagStringField(flag, "DefaultText"); text != "" {
+		return text
+	}
+	if !flag.TakesValue() {
+		return ""
+	}
+	return flag.GetValue()
+}
+
+// roffEscape escapes text so that it is printed as is by roff.
+func roffEscape(s string) string {
+	s = strings.Replace(s, `\`, `\e`, -1)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roffOption escapes the dashes of a command line option so that roff
+// does not render them as hyphens.
+func roffOption(s string) string {
+	return strings.Replace(roffEscape(s), "-", `\-`, -1)
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func newDocsTestApp() *App {
+	return &App{
+		Name:        "greet",
+		Usage:       "fight the loneliness",
+		Description: "greet says hello",
+		Flags: []Flag{
+			&StringFlag{Name: "name", Aliases: []string{"n"}, Value: "bob", Usage: "who to greet"},
+			&BoolFlag{Name: "secret", Hidden: true},
+		},
+		Commands: []*Command{
+			{
+				Name:      "config",
+				Aliases:   []string{"c"},
+				Usage:     "configure the greeting",
+				ArgsUsage: "[key]",
+				Flags:     []Flag{&StringFlag{Name: "file", Usage: "`FILE` to write"}},
+				Subcommands: []*Command{
+					{Name: "show", Usage: "show the configuration"},
+				},
+			},
+			{Name: "info", Usage: "show info", Category: "misc"},
+			{Name: "hidden", Hidden: true},
+		},
+	}
+}
+
+func TestToMarkdown(t *testing.T) {
+	md, err := newDocsTestApp().ToMarkdown()
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+
+	for _, want := range []string{
+		"greet - fight the loneliness",
+		"[--name|-n]=[value]",
+		"greet says hello",
+		`**--name, -n**="bob": who to greet`,
+		"## config, c",
+		"**Usage**: `greet config [command options] [key]`",
+		"**--file**=\"\": FILE to write",
+		"### show",
+		"## misc",
+		"### info",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q, but got:\n%s", want, md)
+		}
+	}
+	for _, unwanted := range []string{"hidden", "secret"} {
+		if strings.Contains(md, unwanted) {
+			t.Errorf("expected markdown not to contain %q, but got:\n%s", unwanted, md)
+		}
+	}
+}
+
+func TestToMan(t *testing.T) {
+	man, err := newDocsTestApp().ToMan()
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+
+	for _, want := range []string{
+		`.TH "GREET" "8"`,
+		`greet \- fight the loneliness`,
+		`\fB\-\-name, \-n\fP="bob"`,
+		".SS config (config, c)",
+		".SS config show",
+		".SS info\n.I misc",
+	} {
+		if !strings.Contains(man, want) {
+			t.Errorf("expected man page to contain %q, but got:\n%s", want, man)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	// ErrorFormatText reports errors as plain text, followed by the help
+	// text for usage errors. This is the default.
+	ErrorFormatText = "text"
+
+	// ErrorFormatJSON reports errors as JSON objects, one per line, with
+	// the exit code, the message and the missing flags if any.
+	ErrorFormatJSON = "json"
+)
+
+// UsageErrorExitCode is the code reported for usage and required flag errors
+// in structured error output.
+var UsageErrorExitCode = 2
+
+// ErrorReport is the structured form of an error reported by App when
+// ErrorFormat is ErrorFormatJSON.
+type ErrorReport struct {
+	// Type is one of "usage", "required_flags", "exit", "multi" or "error"
+	Type         string         `json:"type"`
+	Code         int            `json:"code"`
+	Message      string         `json:"message"`
+	MissingFlags []string       `json:"missing_flags,omitempty"`
+	Errors       []*ErrorReport `json:"errors,omitempty"`
+}
+
+// NewErrorReport converts err into an ErrorReport. The code of a MultiError
+// is the code of its last ExitCoder, like HandleExitCoder uses.
+func NewErrorReport(err error) *ErrorReport {
+	r := &ErrorReport{Type: "error", Code: 1, Message: err.Error()}
+
+	switch e := err.(type) {
+	case MultiError:
+		r.Type = "multi"
+		for _, merr := range e.Errors() {
+			if merr == nil {
+				continue
+			}
+			sub := NewErrorReport(merr)
+			if sub.Type == "multi" || sub.Type == "exit" {
+				r.Code = sub.Code
+			}
+			r.MissingFlags = append(r.MissingFlags, sub.MissingFlags...)
+			r.Errors = append(r.Errors, sub)
+		}
+	case ExitCoder:
+		r.Type = "exit"
+		r.Code = e.ExitCode()
+	case requiredFlagsErr:
+		r.Type = "required_flags"
+		r.Code = UsageErrorExitCode
+		r.MissingFlags = e.getMissingFlags()
+	}
+
+	return r
+}
+
+// markUsage turns the plain errors of the report into usage errors.
+func (r *ErrorReport) markUsage() {
+	switch r.Type {
+	case "error":
+		r.Type = "usage"
+		r.Code = UsageErrorExitCode
+	case "multi":
+		for _, sub := range r.Errors {
+			sub.markUsage()
+			r.Code = sub.Code
+		}
+	}
+}
+
+func writeErrorReport(w io.Writer, r *ErrorReport) {
+	_ = json.NewEncoder(w).Encode(r)
+}
+
+func (a *App) errWriter() io.Writer {
+	if a.ErrWriter != nil {
+		return a.ErrWriter
+	}
+	return ErrWriter
+}
+
+func (a *App) osExiter() func(int) {
+	if a.OsExiter != nil {
+		return a.OsExiter
+	}
+	return OsExiter
+}
+
+// reportUsageError writes err, a usage or required flag error, as a JSON
+// report when the app uses ErrorFormatJSON. It returns false in text mode
+// so that the caller prints the usual usage text instead.
+func (a *App) reportUsageError(err error) bool {
+	if a.ErrorFormat != ErrorFormatJSON {
+		return false
+	}
+
+	r := NewErrorReport(err)
+	r.markUsage()
+	writeErrorReport(a.errWriter(), r)
+	return true
+}
+
+// reportExitCoder is the default exit error handler of an App: it reports
+// ExitCoder and MultiError values in the app's ErrorFormat and exits with
+// their code.
+func (a *App) reportExitCoder(err error) {
+	if a.ErrorFormat != ErrorFormatJSON {
+		handleExitCoder(err, a.errWriter(), a.osExiter())
+		return
+	}
+
+	switch err.(type) {
+	case MultiError, ExitCoder:
+	default:
+		return
+	}
+
+	r := NewErrorReport(err)
+	if r.Message != "" {
+		writeErrorReport(a.errWriter(), r)
+	}
+	a.osExiter()(r.Code)
+}
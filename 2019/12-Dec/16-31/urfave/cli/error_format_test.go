@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+func newErrorFormatTestApp(errOutput *bytes.Buffer, exitCode *int) *App {
+	return &App{
+		Writer:      &bytes.Buffer{},
+		ErrWriter:   errOutput,
+		ErrorFormat: ErrorFormatJSON,
+		OsExiter:    func(code int) { *exitCode = code },
+		Commands: []*Command{
+			{
+				Name:  "get",
+				Flags: []Flag{&StringFlag{Name: "id", Required: true}},
+				Action: func(c *Context) error {
+					return Exit("not found: "+c.String("id"), 4)
+				},
+			},
+		},
+	}
+}
+
+func decodeErrorReport(t *testing.T, output *bytes.Buffer) *ErrorReport {
+	var r ErrorReport
+	if err := json.Unmarshal(output.Bytes(), &r); err != nil {
+		t.Fatalf("expected a JSON error report, but got %q: %s", output.String(), err)
+	}
+	return &r
+}
+
+func TestErrorFormatJSONReportsUsageErrors(t *testing.T) {
+	for _, tt := range []struct {
+		args    []string
+		typ     string
+		missing []string
+	}{
+		{args: []string{"app", "get", "--nope"}, typ: "usage"},
+		{args: []string{"app", "get"}, typ: "required_flags", missing: []string{"id"}},
+	} {
+		output := &bytes.Buffer{}
+		exitCode := -1
+		if err := newErrorFormatTestApp(output, &exitCode).Run(tt.args); err == nil {
+			t.Fatalf("%v: expected an error, but got none", tt.args)
+		}
+
+		r := decodeErrorReport(t, output)
+		if r.Type != tt.typ || r.Code != UsageErrorExitCode || r.Message == "" {
+			t.Errorf("%v: expected %s report with code %d, but got: %+v", tt.args, tt.typ, UsageErrorExitCode, r)
+		}
+		if len(r.MissingFlags) != len(tt.missing) || (len(tt.missing) > 0 && r.MissingFlags[0] != tt.missing[0]) {
+			t.Errorf("%v: expected missing flags %v, but got: %v", tt.args, tt.missing, r.MissingFlags)
+		}
+		if exitCode != -1 {
+			t.Errorf("%v: expected usage errors not to exit, but got exit code %d", tt.args, exitCode)
+		}
+	}
+}
+
+func TestErrorFormatJSONReportsExitCoders(t *testing.T) {
+	output := &bytes.Buffer{}
+	exitCode := -1
+	_ = newErrorFormatTestApp(output, &exitCode).Run([]string{"app", "get", "--id", "42"})
+
+	r := decodeErrorReport(t, output)
+	if r.Type != "exit" || r.Code != 4 || r.Message != "not found: 42" {
+		t.Errorf("expected exit report with code 4, but got: %+v", r)
+	}
+	if exitCode != 4 {
+		t.Errorf("expected exit code 4, but got: %d", exitCode)
+	}
+}
+
+func TestNewErrorReportForMultiError(t *testing.T) {
+	err := newMultiError(errors.New("plain"), Exit("exit", 5), &errRequiredFlags{missingFlags: []string{"id"}})
+
+	r := NewErrorReport(err)
+	if r.Type != "multi" || r.Code != 5 || len(r.Errors) != 3 {
+		t.Fatalf("expected multi report with code 5 and 3 errors, but got: %+v", r)
+	}
+	if len(r.MissingFlags) != 1 || r.MissingFlags[0] != "id" {
+		t.Errorf("expected missing flags [id], but got: %v", r.MissingFlags)
+	}
+	for i, typ := range []string{"error", "exit", "required_flags"} {
+		if r.Errors[i].Type != typ {
+			t.Errorf("expected error %d to be of type %s, but got: %s", i, typ, r.Errors[i].Type)
+		}
+	}
+}
+
+func TestErrorFormatTextWritesToPackageErrWriter(t *testing.T) {
+	defer func(w io.Writer) { ErrWriter = w }(ErrWriter)
+	pkgOutput := &bytes.Buffer{}
+	ErrWriter = pkgOutput
+
+	exitCode := -1
+	app := NewApp()
+	app.Writer = &bytes.Buffer{}
+	app.OsExiter = func(code int) { exitCode = code }
+	app.Action = func(c *Context) error { return Exit("not found", 4) }
+
+	_ = app.Run([]string{"app"})
+	if pkgOutput.String() != "not found\n" || exitCode != 4 {
+		t.Errorf("expected the exit message on the package ErrWriter, but got %q and code %d", pkgOutput.String(), exitCode)
+	}
+
+	// an ErrWriter set on the app takes precedence
+	pkgOutput.Reset()
+	appOutput := &bytes.Buffer{}
+	app.ErrWriter = appOutput
+
+	_ = app.Run([]string{"app"})
+	if appOutput.String() != "not found\n" || pkgOutput.Len() != 0 {
+		t.Errorf("expected the exit message on the app ErrWriter, but got %q and %q", appOutput.String(), pkgOutput.String())
+	}
+}
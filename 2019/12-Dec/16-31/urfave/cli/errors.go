@@ -40,9 +40,7 @@ func (m *multiError) Error() string {
 // Errors returns a copy of tje errors slice
 func (m *multiError) Errors() []error {
 	errs := make([]error, len(*m))
-	for _, err := range *m {
-		errs = append(errs, err)
-	}
+	copy(errs, *m)
 	return errs
 }
 
@@ -89,6 +87,10 @@ func (ee *exitError) ExitCode() int {
 // given exit code. If the given error os a MultiError, then this func is
 // called on all members of the Errors slice and calls OsExiter with the last exit code.
 func HandleExitCoder(err error) {
+	handleExitCoder(err, ErrWriter, OsExiter)
+}
+
+func handleExitCoder(err error, w io.Writer, exiter func(int)) {
 	if err == nil {
 		return
 	}
@@ -96,29 +98,29 @@ func HandleExitCoder(err error) {
 	if exitErr, ok := err.(ExitCoder); ok {
 		if err.Error() != "" {
 			if _, ok := exitErr.(ErrorFormatter); ok {
-				_, _ = fmt.Fprintf(ErrWriter, "%+v\n", err)
+				_, _ = fmt.Fprintf(w, "%+v\n", err)
 			} else {
-				_, _ = fmt.Fprintln(ErrWriter, err)
+				_, _ = fmt.Fprintln(w, err)
 			}
 		}
-		OsExiter(exitErr.ExitCode())
+		exiter(exitErr.ExitCode())
 		return
 	}
 
 	if multiErr, ok := err.(MultiError); ok {
-		code := handleMultiError(multiErr)
-		OsExiter(code)
+		code := handleMultiError(w, multiErr)
+		exiter(code)
 		return
 	}
 }
 
-func handleMultiError(multiErr MultiError) int {
+func handleMultiError(w io.Writer, multiErr MultiError) int {
 	code := 1
 	for _, merr := range multiErr.Errors() {
 		if multiErr2, ok := merr.(MultiError); ok {
-			code = handleMultiError(multiErr2)
+			code = handleMultiError(w, multiErr2)
 		} else if merr != nil {
-			fmt.Fprintln(ErrWriter, merr)
+			_, _ = fmt.Fprintln(w, merr)
 			if exitErr, ok := merr.(ExitCoder); ok {
 				code = exitErr.ExitCode()
 			}
@@ -46,7 +46,7 @@ var FlagStringer FlagStringFunc = stringifyFlag
 
 // Serializer is used to circumvent the limitations of flag.FlagSet.Set
 type Serializer interface {
-	Serializer() string
+	Serialize() string
 }
 
 // FlagNamePrefixer converts a full flag name and its placeholer into the help
@@ -236,6 +236,24 @@ func flagStringField(f Flag, name string) string {
 	return ""
 }
 
+// flagTakesFile reports whether the flag value is a file path, either
+// because it is a PathFlag or because TakesFile is set on the flag.
+func flagTakesFile(f Flag) bool {
+	if _, ok := f.(*PathFlag); ok {
+		return true
+	}
+
+	field := flagValue(f).FieldByName("TakesFile")
+	return field.IsValid() && field.Bool()
+}
+
+// flagIsSecret reports whether Secret is set on the flag, in which case its
+// value is read without echo when prompted for.
+func flagIsSecret(f Flag) bool {
+	field := flagValue(f).FieldByName("Secret")
+	return field.IsValid() && field.Bool()
+}
+
 func withFileHint(filePath, str string) string {
 	fileText := ""
 	if filePath != "" {
@@ -252,15 +270,77 @@ func flagValue(f Flag) reflect.Value {
 	return fv
 }
 
-// func stringifyFlag(f Flag) string {
-// 	fv := flagValue(f)
+func stringifyFlag(f Flag) string {
+	fv := flagValue(f)
+
+	switch f.(type) {
+	case *IntSliceFlag:
+		return withHints(f, stringifyIntSliceFlag(f.(*IntSliceFlag)))
+	case *Int64SliceFlag:
+		return withHints(f, stringifyInt64SliceFlag(f.(*Int64SliceFlag)))
+	case *Float64SliceFlag:
+		return withHints(f, stringifyFloat64SliceFlag(f.(*Float64SliceFlag)))
+	case *StringSliceFlag:
+		return withHints(f, stringifyStringSliceFlag(f.(*StringSliceFlag)))
+	}
+
+	placeholder, usage := unquoteUsage(fv.FieldByName("Usage").String())
+
+	needsPlaceholder := false
+	defaultValueString := ""
+	val := fv.FieldByName("Value")
+	if val.IsValid() {
+		needsPlaceholder = val.Kind() != reflect.Bool
+		defaultValueString = fmt.Sprintf(" (default: %v)", val.Interface())
+
+		if val.Kind() == reflect.String && val.String() != "" {
+			defaultValueString = fmt.Sprintf(" (default: %q)", val.String())
+		}
+	}
+
+	helpText := fv.FieldByName("DefaultText")
+	if helpText.IsValid() && helpText.String() != "" {
+		needsPlaceholder = val.Kind() != reflect.Bool
+		defaultValueString = fmt.Sprintf(" (default: %s)", helpText.String())
+	}
+
+	if defaultValueString == " (default: )" {
+		defaultValueString = ""
+	}
+
+	if needsPlaceholder && placeholder == "" {
+		placeholder = defaultPlaceholder
+	}
+
+	usageWithDefault := strings.TrimSpace(usage + defaultValueString)
+
+	return withHints(f, fmt.Sprintf("%s\t%s", FlagNamePrefixer(f.Names(), placeholder), usageWithDefault))
+}
+
+func withHints(f Flag, str string) string {
+	return FlagFileHinter(flagStringField(f, "FilePath"),
+		FlagEnvHinter(flagStringSliceField(f, "EnvVars"), str))
+}
 
-// 	switch f.(type) {
-// 	case *IntSliceFlag:
-// 		return withEnvHint(flagStringSliceField(f, "EnvVars"),
-// 			stringifyIntSliceFlag(f.(*IntSliceFlag)))
-// 	}
-// }
+func stringifyIntSliceFlag(f *IntSliceFlag) string {
+	var defaultVals []string
+	if f.Value != nil && len(f.Value.Value()) > 0 {
+		for _, i := range f.Value.Value() {
+			defaultVals = append(defaultVals, strconv.Itoa(i))
+		}
+	}
+	return stringifySliceFlag(f.Usage, f.Names(), defaultVals)
+}
+
+func stringifyInt64SliceFlag(f *Int64SliceFlag) string {
+	var defaultVals []string
+	if f.Value != nil && len(f.Value.Value()) > 0 {
+		for _, i := range f.Value.Value() {
+			defaultVals = append(defaultVals, strconv.FormatInt(i, 10))
+		}
+	}
+	return stringifySliceFlag(f.Usage, f.Names(), defaultVals)
+}
 
 func stringifyFloat64SliceFlag(f *Float64SliceFlag) string {
 	var defaultVals []string
@@ -296,7 +376,7 @@ func stringifySliceFlag(usage string, names, defaultVals []string) string {
 	}
 
 	usageWithDefault := strings.TrimSpace(fmt.Sprintf("%s%s", usage, defaultVal))
-	return fmt.Sprintf("%s\n%s", prefixedNames(names, placeholder), usageWithDefault)
+	return fmt.Sprintf("%s\t%s", FlagNamePrefixer(names, placeholder), usageWithDefault)
 }
 
 func hasFlag(flags []Flag, fl Flag) bool {
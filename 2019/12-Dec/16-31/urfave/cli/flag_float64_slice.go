@@ -27,7 +27,7 @@ func (f *Float64Slice) Set(value string) error {
 	}
 
 	if strings.HasPrefix(value, slPfx) {
-		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), f.slice)
+		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), &f.slice)
 		f.hasBeenSet = true
 		return nil
 	}
@@ -72,7 +72,7 @@ type Float64SliceFlag struct {
 	Required    bool
 	Hidden      bool
 	Value       *Float64Slice
-	DEfaultText string
+	DefaultText string
 	HasBeenSet  bool
 }
 
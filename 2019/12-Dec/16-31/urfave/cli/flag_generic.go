@@ -20,7 +20,7 @@ type GenericFlag struct {
 	FilePath    string
 	Required    bool
 	Hidden      bool
-	TalesFile   bool
+	TakesFile   bool
 	Value       Generic
 	DefaultText string
 	HasBeenSet  bool
@@ -57,6 +57,15 @@ func (f *GenericFlag) GetUsage() string {
 	return f.Usage
 }
 
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *GenericFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
 // Apply takes the flafset and calls Set on the generic flag with the value
 // provided by the user for parsing by the flag
 func (f *GenericFlag) Apply(set *flag.FlagSet) error {
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlagGroup is a set of mutually exclusive flags: at most one of them may
+// be set, or exactly one when Required is true. Flags are referred to by
+// any of their names.
+type FlagGroup struct {
+	Flags    []string
+	Required bool
+}
+
+// FlagDependency declares that Flag may only be set together with every
+// flag in Requires.
+type FlagDependency struct {
+	Flag     string
+	Requires []string
+}
+
+type errFlagGroupConflict struct {
+	setFlags []string
+}
+
+func (e *errFlagGroupConflict) Error() string {
+	return fmt.Sprintf("Flags %q are mutually exclusive", strings.Join(e.setFlags, ", "))
+}
+
+type errRequiredFlagGroup struct {
+	groupFlags []string
+}
+
+func (e *errRequiredFlagGroup) Error() string {
+	return fmt.Sprintf("One of the flags %q is required", strings.Join(e.groupFlags, ", "))
+}
+
+func (e *errRequiredFlagGroup) getMissingFlags() []string {
+	return e.groupFlags
+}
+
+type errFlagDependency struct {
+	flag         string
+	missingFlags []string
+}
+
+func (e *errFlagDependency) Error() string {
+	if len(e.missingFlags) == 1 {
+		return fmt.Sprintf("Flag %q requires flag %q", e.flag, e.missingFlags[0])
+	}
+	return fmt.Sprintf("Flag %q requires flags %q", e.flag, strings.Join(e.missingFlags, ", "))
+}
+
+func (e *errFlagDependency) getMissingFlags() []string {
+	return e.missingFlags
+}
+
+// checkFlagConstraints runs checkRequiredFlags and validates the flag
+// groups and dependencies. A single failure is returned as is, several
+// failures are wrapped in a MultiError.
+func checkFlagConstraints(flags []Flag, groups []FlagGroup, deps []FlagDependency, context *Context) error {
+	var errs []error
+	if err := checkRequiredFlags(flags, context); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, group := range groups {
+		var setFlags, groupFlags []string
+		for _, name := range group.Flags {
+			displayName := flagDisplayName(flags, name)
+			groupFlags = append(groupFlags, displayName)
+			if flagIsSet(flags, name, context) {
+				setFlags = append(setFlags, displayName)
+			}
+		}
+		if len(setFlags) > 1 {
+			errs = append(errs, &errFlagGroupConflict{setFlags: setFlags})
+		} else if len(setFlags) == 0 && group.Required {
+			errs = append(errs, &errRequiredFlagGroup{groupFlags: groupFlags})
+		}
+	}
+
+	for _, dep := range deps {
+		if !flagIsSet(flags, dep.Flag, context) {
+			continue
+		}
+		var missingFlags []string
+		for _, name := range dep.Requires {
+			if !flagIsSet(flags, name, context) {
+				missingFlags = append(missingFlags, flagDisplayName(flags, name))
+			}
+		}
+		if len(missingFlags) != 0 {
+			errs = append(errs, &errFlagDependency{
+				flag:         flagDisplayName(flags, dep.Flag),
+				missingFlags: missingFlags,
+			})
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return newMultiError(errs...)
+	}
+}
+
+// findFlag returns the flag of flags that has name as one of its names.
+func findFlag(flags []Flag, name string) Flag {
+	for _, f := range flags {
+		for _, n := range f.Names() {
+			if strings.TrimSpace(n) == name {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// flagIsSet reports whether the flag known as name was set under any of
+// its names.
+func flagIsSet(flags []Flag, name string, context *Context) bool {
+	f := findFlag(flags, name)
+	if f == nil {
+		return context.IsSet(name)
+	}
+	for _, n := range f.Names() {
+		if context.IsSet(strings.TrimSpace(n)) {
+			return true
+		}
+	}
+	return false
+}
+
+// flagDisplayName returns the name used for name in error messages, which
+// is the same name checkRequiredFlags reports for the flag.
+func flagDisplayName(flags []Flag, name string) string {
+	f := findFlag(flags, name)
+	if f == nil {
+		return name
+	}
+	var displayName string
+	for _, n := range f.Names() {
+		if n = strings.TrimSpace(n); len(n) > 1 || displayName == "" {
+			displayName = n
+		}
+	}
+	return displayName
+}
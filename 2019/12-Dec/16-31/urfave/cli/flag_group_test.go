@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newFlagGroupTestApp() *App {
+	return &App{
+		Writer: &bytes.Buffer{},
+		Commands: []*Command{
+			{
+				Name: "export",
+				Flags: []Flag{
+					&BoolFlag{Name: "json", Aliases: []string{"j"}},
+					&BoolFlag{Name: "yaml"},
+					&BoolFlag{Name: "csv"},
+					&StringFlag{Name: "tls-cert"},
+					&StringFlag{Name: "tls-key"},
+				},
+				FlagGroups: []FlagGroup{
+					{Flags: []string{"json", "yaml", "csv"}, Required: true},
+				},
+				FlagDependencies: []FlagDependency{
+					{Flag: "tls-cert", Requires: []string{"tls-key"}},
+				},
+				Action: func(c *Context) error { return nil },
+			},
+		},
+	}
+}
+
+func TestFlagConstraints(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"app", "export", "--json"}},
+		{args: []string{"app", "export", "--yaml", "--tls-cert", "c", "--tls-key", "k"}},
+		{args: []string{"app", "export", "-j", "--csv"}, want: `Flags "json, csv" are mutually exclusive`},
+		{args: []string{"app", "export"}, want: `One of the flags "json, yaml, csv" is required`},
+		{args: []string{"app", "export", "--csv", "--tls-cert", "c"}, want: `Flag "tls-cert" requires flag "tls-key"`},
+		{
+			args: []string{"app", "export", "--tls-cert", "c"},
+			want: "One of the flags \"json, yaml, csv\" is required\nFlag \"tls-cert\" requires flag \"tls-key\"",
+		},
+	} {
+		err := newFlagGroupTestApp().Run(tt.args)
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("%v: expected no error, but got: %s", tt.args, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%v: expected error %q, but got: %v", tt.args, tt.want, err)
+		}
+	}
+}
+
+func TestFlagConstraintsReportMissingFlags(t *testing.T) {
+	err := newFlagGroupTestApp().Run([]string{"app", "export"})
+	rerr, ok := err.(requiredFlagsErr)
+	if !ok {
+		t.Fatalf("expected required flags error, but got: %v", err)
+	}
+	if got := rerr.getMissingFlags(); len(got) != 3 {
+		t.Errorf("expected the three group flags to be missing, but got: %v", got)
+	}
+
+	err = newFlagGroupTestApp().Run([]string{"app", "export", "--tls-cert", "c"})
+	if merr, ok := err.(MultiError); !ok || len(merr.Errors()) != 2 {
+		t.Errorf("expected a multi error with two errors, but got: %v", err)
+	}
+}
@@ -40,6 +40,12 @@ func (s *StringSlice) String() string {
 	return fmt.Sprintf("%s", s.slice)
 }
 
+// Serialize allows StringSlice to fulfill Serializer
+func (s *StringSlice) Serialize() string {
+	jsonBytes, _ := json.Marshal(s.slice)
+	return fmt.Sprintf("%s%s", slPfx, string(jsonBytes))
+}
+
 // Value returns the slice of strings set by this flag
 func (s *StringSlice) Value() []string {
 	return s.slice
@@ -57,7 +63,7 @@ type StringSliceFlag struct {
 	Usage       string
 	EnvVars     []string
 	FilePath    string
-	Requried    bool
+	Required    bool
 	Hidden      bool
 	TakesFile   bool
 	Value       *StringSlice
@@ -83,7 +89,12 @@ func (f *StringSliceFlag) Names() []string {
 
 // IsRequired returns whether or not the flag is required
 func (f *StringSliceFlag) IsRequired() bool {
-	return f.Requried
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *StringSliceFlag) TakesValue() bool {
+	return true
 }
 
 // GetUsage returns the usage string for the flag
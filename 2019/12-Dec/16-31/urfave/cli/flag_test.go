@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+)
+
+var (
+	_ DocGenerationFlag = (*StringFlag)(nil)
+	_ DocGenerationFlag = (*PathFlag)(nil)
+	_ DocGenerationFlag = (*UintFlag)(nil)
+	_ DocGenerationFlag = (*Uint64Flag)(nil)
+	_ DocGenerationFlag = (*TimestampFlag)(nil)
+	_ RequiredFlag      = (*StringFlag)(nil)
+	_ RequiredFlag      = (*PathFlag)(nil)
+	_ RequiredFlag      = (*UintFlag)(nil)
+	_ RequiredFlag      = (*Uint64Flag)(nil)
+	_ RequiredFlag      = (*TimestampFlag)(nil)
+)
+
+func TestScalarFlagsParseFromArgs(t *testing.T) {
+	var (
+		name      string
+		path      string
+		workers   uint
+		size      uint64
+		createdAt *time.Time
+	)
+
+	app := &App{
+		Writer: &bytes.Buffer{},
+		Flags: []Flag{
+			&StringFlag{Name: "name", Aliases: []string{"n"}},
+			&PathFlag{Name: "config"},
+			&UintFlag{Name: "workers", Value: 2},
+			&Uint64Flag{Name: "size"},
+			&TimestampFlag{Name: "created-at", Layout: "2006-01-02"},
+		},
+		Action: func(c *Context) error {
+			name = c.String("name")
+			path = c.Path("config")
+			workers = c.Uint("workers")
+			size = c.Uint64("size")
+			createdAt = c.Timestamp("created-at")
+			return nil
+		},
+	}
+
+	err := app.Run([]string{"app", "-n", "gopher", "--config", "/etc/app.yaml", "--size", "18446744073709551615", "--created-at", "2019-12-24"})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+
+	if name != "gopher" {
+		t.Errorf("expected name to be gopher, but got: %q", name)
+	}
+	if path != "/etc/app.yaml" {
+		t.Errorf("expected config path to be /etc/app.yaml, but got: %q", path)
+	}
+	if workers != 2 {
+		t.Errorf("expected default workers to be 2, but got: %d", workers)
+	}
+	if size != 18446744073709551615 {
+		t.Errorf("expected size to be max uint64, but got: %d", size)
+	}
+	if createdAt == nil || !createdAt.Equal(time.Date(2019, 12, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected created-at to be 2019-12-24, but got: %v", createdAt)
+	}
+}
+
+func TestScalarFlagsApplyFromEnv(t *testing.T) {
+	os.Setenv("APP_WORKERS", "8")
+	os.Setenv("APP_SINCE", "2019-12-01")
+	defer os.Unsetenv("APP_WORKERS")
+	defer os.Unsetenv("APP_SINCE")
+
+	workers := &UintFlag{Name: "workers", EnvVars: []string{"APP_WORKERS"}}
+	since := &TimestampFlag{Name: "since", Layout: "2006-01-02", EnvVars: []string{"APP_SINCE"}}
+
+	set, err := flagSet("app", []Flag{workers, since})
+	if err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+
+	if !workers.IsSet() || lookupUint("workers", set) != 8 {
+		t.Errorf("expected workers to be set to 8 from env, but got: %d", lookupUint("workers", set))
+	}
+	if !since.IsSet() || since.GetValue() != "2019-12-01" {
+		t.Errorf("expected since to be set from env, but got: %q", since.GetValue())
+	}
+}
+
+func TestTimestampFlagRequiresLayout(t *testing.T) {
+	_, err := flagSet("app", []Flag{&TimestampFlag{Name: "since"}})
+	if err == nil {
+		t.Error("expected error for timestamp flag without layout, but got none")
+	}
+}
+
+func TestFlagStringer(t *testing.T) {
+	for _, tt := range []struct {
+		flag Flag
+		want string
+	}{
+		{&StringFlag{Name: "name", Value: "gopher", Usage: "your name"}, "--name value\tyour name (default: \"gopher\")"},
+		{&PathFlag{Name: "config", Usage: "load `FILE`", FilePath: "/etc/app/config"}, "--config FILE\tload FILE [/etc/app/config]"},
+		{&UintFlag{Name: "workers", Aliases: []string{"w"}, Value: 4}, "--workers value, -w value\t(default: 4)"},
+		{&TimestampFlag{Name: "since", Layout: "2006-01-02", DefaultText: "today"}, "--since value\t(default: today)"},
+	} {
+		if got := tt.flag.String(); got != tt.want {
+			t.Errorf("expected %q, but got: %q", tt.want, got)
+		}
+	}
+}
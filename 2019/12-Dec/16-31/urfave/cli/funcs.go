@@ -39,3 +39,11 @@ type FlagEnvHintFunc func(envVars []string, str string) string
 // FlagFileHintFunc is used by the default FlagStringFunc to annotate flag help
 // with the file path details.
 type FlagFileHintFunc func(filePath, str string) string
+
+// SuggestCommandFunc returns the name of the command closest to provided,
+// or an empty string if there is no good match.
+type SuggestCommandFunc func(commands []*Command, provided string) string
+
+// SuggestFlagFunc returns the prefixed name of the flag closest to provided,
+// or an empty string if there is no good match.
+type SuggestFlagFunc func(flags []Flag, provided string, hideHelp bool) string
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestShowAppHelpListsCategoriesAndFlags(t *testing.T) {
+	output := &bytes.Buffer{}
+	app := &App{
+		Name:   "app",
+		Writer: output,
+		Flags: []Flag{
+			&StringSliceFlag{Name: "tag", Usage: "`TAG` to apply", EnvVars: []string{"APP_TAG"}},
+			&BoolFlag{Name: "secret", Hidden: true},
+		},
+		Commands: []*Command{
+			{Name: "add", Aliases: []string{"a"}, Usage: "add a file", Category: "files"},
+			{Name: "hidden", Hidden: true},
+		},
+	}
+
+	if err := app.Run([]string{"app", "--help"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+
+	help := output.String()
+	for _, want := range []string{"files:", "add, a", "help, h", "--tag TAG", "[$APP_TAG]"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("expected help to contain %q, but got:\n%s", want, help)
+		}
+	}
+	for _, unwanted := range []string{"hidden", "--secret", "--version"} {
+		if strings.Contains(help, unwanted) {
+			t.Errorf("expected help not to contain %q, but got:\n%s", unwanted, help)
+		}
+	}
+}
+
+func TestShowCommandHelpUsesCustomTemplate(t *testing.T) {
+	output := &bytes.Buffer{}
+	app := &App{
+		Writer: output,
+		Commands: []*Command{
+			{Name: "frobnicate", CustomHelpTemplate: "custom {{.Name}}\n"},
+		},
+	}
+
+	if err := app.Run([]string{"app", "help", "frobnicate"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	if output.String() != "custom frobnicate\n" {
+		t.Errorf("expected custom help, but got: %q", output.String())
+	}
+}
+
+func TestShowVersion(t *testing.T) {
+	output := &bytes.Buffer{}
+	app := &App{
+		Name:      "app",
+		Version:   "1.2.3",
+		Copyright: "(c) gy-kim",
+		Authors:   []*Author{{Name: "A"}, {Name: "B", Email: "b@example.com"}},
+		Writer:    output,
+	}
+
+	if err := app.Run([]string{"app", "-v"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+
+	version := output.String()
+	for _, want := range []string{"app version 1.2.3", "authors: A, B <b@example.com>", "(c) gy-kim"} {
+		if !strings.Contains(version, want) {
+			t.Errorf("expected version output to contain %q, but got:\n%s", want, version)
+		}
+	}
+}
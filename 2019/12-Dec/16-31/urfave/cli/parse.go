@@ -8,11 +8,18 @@ import (
 type iterativeParser interface {
 	newFlagSet() (*flag.FlagSet, error)
 	useShortOptionHandling() bool
+	allowInterspersed() bool
+	isSubcommand(name string) bool
 }
 
 func parseIter(set *flag.FlagSet, ip iterativeParser, args []string, shellComplete bool) error {
 	for {
-		err := set.Parse(args)
+		parseArgs := args
+		if ip.allowInterspersed() {
+			parseArgs = interspersedArgs(set, ip, args)
+		}
+
+		err := set.Parse(parseArgs)
 		if !ip.useShortOptionHandling() || err == nil {
 			if shellComplete {
 				return nil
@@ -28,7 +35,14 @@ func parseIter(set *flag.FlagSet, ip iterativeParser, args []string, shellComple
 
 		argsWereSplit := false
 		for i, arg := range args {
-			if name := strings.TrimLeft(arg, "-"); name != trimmed {
+			if arg == "--" {
+				break
+			}
+			name := strings.TrimLeft(arg, "-")
+			if eq := strings.Index(name, "="); eq >= 0 {
+				name = name[:eq]
+			}
+			if name != trimmed {
 				continue
 			}
 
@@ -54,6 +68,55 @@ func parseIter(set *flag.FlagSet, ip iterativeParser, args []string, shellComple
 	}
 }
 
+// interspersedArgs moves the flags found after positional arguments in
+// front of them, so that flag.FlagSet.Parse, which stops at the first
+// positional argument, sees them. Everything after a "--" terminator or a
+// leading subcommand name is kept as positional arguments.
+func interspersedArgs(set *flag.FlagSet, ip iterativeParser, args []string) []string {
+	var flags, positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+
+		if len(arg) < 2 || arg[0] != '-' {
+			if len(positional) == 0 && ip.isSubcommand(arg) {
+				positional = append(positional, args[i:]...)
+				break
+			}
+			positional = append(positional, arg)
+			continue
+		}
+
+		flags = append(flags, arg)
+		if !strings.Contains(arg, "=") && flagTakesValueArg(set, arg) && i+1 < len(args) {
+			i++
+			flags = append(flags, args[i])
+		}
+	}
+
+	if len(positional) == 0 {
+		return flags
+	}
+	return append(append(flags, "--"), positional...)
+}
+
+// flagTakesValueArg reports whether the flag arg is followed by its value
+// as a separate argument. Unknown flags, such as combined short options
+// that are not split yet, are assumed not to.
+func flagTakesValueArg(set *flag.FlagSet, arg string) bool {
+	f := set.Lookup(strings.TrimLeft(arg, "-"))
+	if f == nil {
+		return false
+	}
+	if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && bf.IsBoolFlag() {
+		return false
+	}
+	return true
+}
+
 func splitShortOptions(set *flag.FlagSet, arg string) []string {
 	shortFlagsExist := func(s string) bool {
 		for _, c := range s[1:] {
@@ -64,13 +127,20 @@ func splitShortOptions(set *flag.FlagSet, arg string) []string {
 		return true
 	}
 
-	if !isSplittable(arg) || !shortFlagsExist(arg) {
+	// a value given as -abc=value belongs to the last short option
+	name, value := arg, ""
+	if i := strings.Index(arg, "="); i >= 0 {
+		name, value = arg[:i], arg[i:]
+	}
+
+	if !isSplittable(name) || !shortFlagsExist(name) {
 		return []string{arg}
 	}
-	separated := make([]string, 0, len(arg)-1)
-	for _, flagChar := range arg[1:] {
+	separated := make([]string, 0, len(name)-1)
+	for _, flagChar := range name[1:] {
 		separated = append(separated, "-"+string(flagChar))
 	}
+	separated[len(separated)-1] += value
 	return separated
 }
 
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAllowInterspersed(t *testing.T) {
+	for _, tt := range []struct {
+		args       []string
+		verbose    bool
+		output     string
+		positional []string
+	}{
+		{args: []string{"app", "cp", "a.txt", "--verbose", "b.txt"}, verbose: true, positional: []string{"a.txt", "b.txt"}},
+		{args: []string{"app", "cp", "a.txt", "--output", "out", "b.txt"}, output: "out", positional: []string{"a.txt", "b.txt"}},
+		{args: []string{"app", "cp", "a.txt", "--output=out"}, output: "out", positional: []string{"a.txt"}},
+		{args: []string{"app", "cp", "a.txt", "-vo", "out"}, verbose: true, output: "out", positional: []string{"a.txt"}},
+		{args: []string{"app", "cp", "a.txt", "-vo=out"}, verbose: true, output: "out", positional: []string{"a.txt"}},
+		{args: []string{"app", "cp", "a.txt", "--", "--verbose", "-vo"}, positional: []string{"a.txt", "--verbose", "-vo"}},
+		{args: []string{"app", "--debug", "cp", "a.txt", "-v"}, verbose: true, positional: []string{"a.txt"}},
+	} {
+		var (
+			verbose    bool
+			output     string
+			positional []string
+		)
+
+		app := &App{
+			Writer:                 &bytes.Buffer{},
+			AllowInterspersed:      true,
+			UseShortOptionHandling: true,
+			Flags:                  []Flag{&BoolFlag{Name: "debug"}},
+			Commands: []*Command{
+				{
+					Name: "cp",
+					Flags: []Flag{
+						&BoolFlag{Name: "verbose", Aliases: []string{"v"}},
+						&StringFlag{Name: "output", Aliases: []string{"o"}},
+					},
+					Action: func(c *Context) error {
+						verbose = c.Bool("verbose")
+						output = c.String("output")
+						positional = c.Args().Slice()
+						return nil
+					},
+				},
+			},
+		}
+
+		if err := app.Run(tt.args); err != nil {
+			t.Fatalf("%v: expected no error, but got: %s", tt.args, err)
+		}
+		if verbose != tt.verbose || output != tt.output {
+			t.Errorf("%v: expected verbose %v and output %q, but got: %v and %q", tt.args, tt.verbose, tt.output, verbose, output)
+		}
+		if strings.Join(positional, " ") != strings.Join(tt.positional, " ") {
+			t.Errorf("%v: expected arguments %v, but got: %v", tt.args, tt.positional, positional)
+		}
+	}
+}
+
+func TestInterspersedFlagsIgnoredByDefault(t *testing.T) {
+	var verbose bool
+	app := &App{
+		Writer: &bytes.Buffer{},
+		Flags:  []Flag{&BoolFlag{Name: "verbose"}},
+		Action: func(c *Context) error {
+			verbose = c.Bool("verbose")
+			return nil
+		},
+	}
+
+	if err := app.Run([]string{"app", "file.txt", "--verbose"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	if verbose {
+		t.Error("expected verbose after a positional argument to be ignored")
+	}
+}
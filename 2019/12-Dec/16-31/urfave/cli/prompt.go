@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+)
+
+// promptForMissingFlags asks for the value of every required flag that is
+// not set, when the app has PromptForMissing enabled and reads from a
+// terminal. Values go through the flag's Set so they are validated like
+// values from the command line, and invalid values are asked for again.
+// Flags left unset are reported by checkFlagConstraints afterwards.
+func (a *App) promptForMissingFlags(flags []Flag, context *Context) {
+	if !a.PromptForMissing || !isTerminal(a.Reader) || context.flagSet == nil {
+		return
+	}
+
+	for _, f := range flags {
+		rf, ok := f.(RequiredFlag)
+		if !ok || !rf.IsRequired() || len(f.Names()) == 0 {
+			continue
+		}
+
+		name := strings.TrimSpace(f.Names()[0])
+		if flagIsSet(flags, name, context) {
+			continue
+		}
+
+		for {
+			value, err := a.promptFlag(f)
+			if err != nil {
+				return
+			}
+			if value == "" {
+				continue
+			}
+			if err := setFlagNames(context.flagSet, f.Names(), value); err != nil {
+				_, _ = fmt.Fprintf(a.Writer, "Invalid value for %s: %v\n", flagDisplayName(flags, name), err)
+				continue
+			}
+			break
+		}
+	}
+}
+
+// setFlagNames sets value for every name of a flag, so it is read the same
+// through its aliases. Names sharing the value of an earlier name, like the
+// ones of slice flags, are set only once.
+func setFlagNames(set *flag.FlagSet, names []string, value string) error {
+	var done []flag.Value
+	for _, name := range names {
+		f := set.Lookup(strings.TrimSpace(name))
+		if f == nil || containsFlagValue(done, f.Value) {
+			continue
+		}
+		if err := set.Set(f.Name, value); err != nil {
+			return err
+		}
+		done = append(done, f.Value)
+	}
+	return nil
+}
+
+func containsFlagValue(values []flag.Value, v flag.Value) bool {
+	if !reflect.TypeOf(v).Comparable() {
+		return false
+	}
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *App) promptFlag(f Flag) (string, error) {
+	label := flagDisplayName([]Flag{f}, strings.TrimSpace(f.Names()[0]))
+	label = prefixFor(label) + label
+	if df, ok := f.(DocGenerationFlag); ok {
+		if _, usage := unquoteUsage(df.GetUsage()); usage != "" {
+			label += " (" + usage + ")"
+		}
+	}
+	_, _ = fmt.Fprintf(a.Writer, "%s: ", label)
+
+	if !flagIsSecret(f) {
+		return readLine(a.Reader)
+	}
+
+	restore := disableEcho(a.Reader)
+	value, err := readLine(a.Reader)
+	restore()
+	// the newline typed by the user was not echoed
+	_, _ = fmt.Fprintln(a.Writer)
+	return value, err
+}
+
+// isTerminal reports whether r looks like an interactive terminal. Readers
+// other than files, such as the ones used in tests, are treated as one.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return r != nil
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// readLine reads a line from r one byte at a time, so that nothing after
+// the line is consumed from the app reader.
+func readLine(r io.Reader) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+			line = append(line, buf[0])
+		}
+		if err == io.EOF && len(line) > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimRight(string(line), "\r"), nil
+}
+
+// disableEcho turns off the echo of a terminal reader with stty and returns
+// a func restoring it. Readers which are not terminals, and systems without
+// stty, are left alone.
+func disableEcho(r io.Reader) func() {
+	f, ok := r.(*os.File)
+	if !ok || !isTerminal(f) {
+		return func() {}
+	}
+
+	stty := func(arg string) error {
+		cmd := exec.Command("stty", arg)
+		cmd.Stdin = f
+		return cmd.Run()
+	}
+	if err := stty("-echo"); err != nil {
+		return func() {}
+	}
+	return func() { _ = stty("echo") }
+}
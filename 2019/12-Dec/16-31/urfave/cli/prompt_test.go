@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func newPromptTestApp(input string, output *bytes.Buffer, got map[string]interface{}) *App {
+	return &App{
+		Reader:           strings.NewReader(input),
+		Writer:           output,
+		PromptForMissing: true,
+		Commands: []*Command{
+			{
+				Name: "login",
+				Flags: []Flag{
+					&StringFlag{Name: "user", Usage: "user name", Required: true},
+					&StringFlag{Name: "password", Required: true, Secret: true},
+					&IntFlag{Name: "port", Required: true},
+				},
+				Action: func(c *Context) error {
+					got["user"] = c.String("user")
+					got["password"] = c.String("password")
+					got["port"] = c.Int("port")
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func TestPromptForMissingFlags(t *testing.T) {
+	output := &bytes.Buffer{}
+	got := map[string]interface{}{}
+	app := newPromptTestApp("gopher\nsecret\neighty\n8080\n", output, got)
+
+	if err := app.Run([]string{"app", "login"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	if got["user"] != "gopher" || got["password"] != "secret" || got["port"] != 8080 {
+		t.Errorf("expected prompted values, but got: %v", got)
+	}
+
+	prompts := output.String()
+	for _, want := range []string{"--user (user name): ", "--password: \n", "--port: ", "Invalid value for port"} {
+		if !strings.Contains(prompts, want) {
+			t.Errorf("expected prompts to contain %q, but got:\n%s", want, prompts)
+		}
+	}
+	if strings.Contains(prompts, "secret") {
+		t.Errorf("expected secret not to be written, but got:\n%s", prompts)
+	}
+}
+
+func TestPromptForMissingSkipsSetFlags(t *testing.T) {
+	output := &bytes.Buffer{}
+	got := map[string]interface{}{}
+	app := newPromptTestApp("secret\n", output, got)
+
+	err := app.Run([]string{"app", "login", "--user", "gopher"})
+	if _, ok := err.(requiredFlagsErr); !ok {
+		t.Fatalf("expected required flags error once input runs out, but got: %v", err)
+	}
+	if strings.Contains(output.String(), "--user (user name): ") {
+		t.Errorf("expected no prompt for the set user flag, but got:\n%s", output.String())
+	}
+	if !strings.Contains(err.Error(), "port") {
+		t.Errorf("expected port to be reported missing, but got: %s", err)
+	}
+}
+
+func TestPromptForMissingSetsAliases(t *testing.T) {
+	var user string
+	var scopes, scopesAlias []string
+	app := &App{
+		Reader:           strings.NewReader("gopher\nread\n"),
+		Writer:           &bytes.Buffer{},
+		PromptForMissing: true,
+		Flags: []Flag{
+			&StringFlag{Name: "user", Aliases: []string{"u"}, Required: true},
+			&StringSliceFlag{Name: "scope", Aliases: []string{"s"}, Required: true},
+		},
+		Action: func(c *Context) error {
+			user = c.String("u")
+			scopes, scopesAlias = c.StringSlice("scope"), c.StringSlice("s")
+			return nil
+		},
+	}
+
+	if err := app.Run([]string{"app"}); err != nil {
+		t.Fatalf("expected no error, but got: %s", err)
+	}
+	if user != "gopher" {
+		t.Errorf("expected prompted user through alias, but got: %q", user)
+	}
+	if strings.Join(scopes, ",") != "read" || strings.Join(scopesAlias, ",") != "read" {
+		t.Errorf("expected prompted scope once through both names, but got: %v and %v", scopes, scopesAlias)
+	}
+}
@@ -16,7 +16,7 @@ func lexicographicLess(i, j string) bool {
 		jr := jRunes[index]
 
 		if lir, ljr := unicode.ToLower(ir), unicode.ToLower(jr); lir != ljr {
-			return lir < lir
+			return lir < ljr
 		}
 
 		if ir != jr {
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SuggestDidYouMeanTemplate is the format used to propose the closest match
+// for an unknown command or flag.
+var SuggestDidYouMeanTemplate = "Did you mean %q?"
+
+// suggestionThreshold is the minimum Jaro-Winkler similarity a command or
+// flag name needs to be proposed as a suggestion.
+const suggestionThreshold = 0.7
+
+// flagFromError extracts the name of the undefined flag from an error
+// returned by the flag package, which looks like:
+//
+//	flag provided but not defined: -foo
+func flagFromError(err error) (string, error) {
+	const errPrefix = "flag provided but not defined: -"
+	errStr := err.Error()
+	if !strings.HasPrefix(errStr, errPrefix) {
+		return "", errors.New("no flag name in error")
+	}
+	return strings.TrimLeft(strings.TrimPrefix(errStr, errPrefix), "-"), nil
+}
+
+// suggestCommand returns the name or alias of the visible command closest
+// to provided, or an empty string if none is close enough.
+func suggestCommand(commands []*Command, provided string) string {
+	distance := 0.0
+	suggestion := ""
+	for _, command := range commands {
+		if command.Hidden {
+			continue
+		}
+		for _, name := range command.Names() {
+			if newDistance := jaroWinkler(name, provided); newDistance > distance {
+				distance = newDistance
+				suggestion = name
+			}
+		}
+	}
+	if distance < suggestionThreshold {
+		return ""
+	}
+	return suggestion
+}
+
+// suggestFlag returns the prefixed name of the visible flag closest to
+// provided, or an empty string if none is close enough. The help flag is
+// considered too unless hideHelp is set.
+func suggestFlag(flags []Flag, provided string, hideHelp bool) string {
+	flags = visibleFlags(flags)
+	if !hideHelp && HelpFlag != nil {
+		flags = append(flags, HelpFlag)
+	}
+
+	distance := 0.0
+	suggestion := ""
+	for _, flag := range flags {
+		for _, name := range flag.Names() {
+			name = strings.TrimSpace(name)
+			if newDistance := jaroWinkler(name, provided); newDistance > distance {
+				distance = newDistance
+				suggestion = name
+			}
+		}
+	}
+	if distance < suggestionThreshold {
+		return ""
+	}
+	return prefixFor(suggestion) + suggestion
+}
+
+// suggestCommandMessage returns the "did you mean" line for an unknown
+// command name, or an empty string when suggestions are disabled or there
+// is nothing close enough.
+func (a *App) suggestCommandMessage(name string) string {
+	if !a.Suggest {
+		return ""
+	}
+	suggest := a.SuggestCommandFunc
+	if suggest == nil {
+		suggest = suggestCommand
+	}
+	if suggestion := suggest(a.Commands, name); suggestion != "" {
+		return fmt.Sprintf(SuggestDidYouMeanTemplate, suggestion)
+	}
+	return ""
+}
+
+// suggestFlagMessage returns the "did you mean" line for a parse error
+// about an undefined flag, looked up among flags.
+func (a *App) suggestFlagMessage(flags []Flag, err error) string {
+	if !a.Suggest {
+		return ""
+	}
+	name, parseErr := flagFromError(err)
+	if parseErr != nil {
+		return ""
+	}
+	suggest := a.SuggestFlagFunc
+	if suggest == nil {
+		suggest = suggestFlag
+	}
+	if suggestion := suggest(flags, name, a.HideHelp); suggestion != "" {
+		return fmt.Sprintf(SuggestDidYouMeanTemplate, suggestion)
+	}
+	return ""
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b, between 0 for
+// no similarity and 1 for equal strings.
+func jaroWinkler(a, b string) float64 {
+	s1, s2 := []rune(a), []rune(b)
+	if len(s1) == 0 && len(s2) == 0 {
+		return 1
+	}
+	if len(s1) == 0 || len(s2) == 0 {
+		return 0
+	}
+
+	longest := len(s1)
+	if len(s2) > longest {
+		longest = len(s2)
+	}
+	window := longest/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matched1 := make([]bool, len(s1))
+	matched2 := make([]bool, len(s2))
+	matches := 0
+	for i := range s1 {
+		lo, hi := i-window, i+window+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(s2) {
+			hi = len(s2)
+		}
+		for j := lo; j < hi; j++ {
+			if matched2[j] || s1[i] != s2[j] {
+				continue
+			}
+			matched1[i], matched2[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, k := 0, 0
+	for i := range s1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[k] {
+			k++
+		}
+		if s1[i] != s2[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(s1)) + m/float64(len(s2)) + (m-float64(transpositions)/2)/m) / 3
+
+	// boost strings sharing a common prefix of up to four runes
+	prefix := 0
+	for prefix < 4 && prefix < len(s1) && prefix < len(s2) && s1[prefix] == s2[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want float64
+	}{
+		{"MARTHA", "MARHTA", 0.961},
+		{"DIXON", "DICKSONX", 0.813},
+		{"hello", "hello", 1},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+	} {
+		if got := jaroWinkler(tt.a, tt.b); math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("jaroWinkler(%q, %q): expected %.3f, but got: %.3f", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func newSuggestTestApp(output *bytes.Buffer) *App {
+	return &App{
+		Name:           "app",
+		Writer:         output,
+		ErrWriter:      output,
+		Suggest:        true,
+		ExitErrHandler: func(c *Context, err error) {},
+		Flags:          []Flag{&StringFlag{Name: "config"}},
+		Commands: []*Command{
+			{Name: "install", Aliases: []string{"i"}, Flags: []Flag{&BoolFlag{Name: "force"}}},
+			{
+				Name:        "remote",
+				Flags:       []Flag{&StringFlag{Name: "origin"}},
+				Subcommands: []*Command{{Name: "add", Flags: []Flag{&BoolFlag{Name: "fetch"}}}},
+			},
+			{Name: "uninstall"},
+			{Name: "secret", Hidden: true},
+		},
+	}
+}
+
+func TestSuggestCommand(t *testing.T) {
+	for provided, want := range map[string]string{
+		"instal":   `No help topic for 'instal'. Did you mean "install"?`,
+		"secrte":   `No help topic for 'secrte'`,
+		"zzzzzzzz": `No help topic for 'zzzzzzzz'`,
+	} {
+		err := newSuggestTestApp(&bytes.Buffer{}).Run([]string{"app", provided})
+		if err == nil || err.Error() != want {
+			t.Errorf("%s: expected error %q, but got: %v", provided, want, err)
+		}
+	}
+}
+
+func TestSuggestFlag(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"app", "--confg", "x"}, want: `Did you mean "--config"?`},
+		{args: []string{"app", "install", "--forse"}, want: `Did you mean "--force"?`},
+		{args: []string{"app", "remote", "add", "--fecth"}, want: `Did you mean "--fetch"?`},
+	} {
+		output := &bytes.Buffer{}
+		if err := newSuggestTestApp(output).Run(tt.args); err == nil {
+			t.Fatalf("%v: expected usage error, but got none", tt.args)
+		}
+		if !strings.Contains(output.String(), tt.want) {
+			t.Errorf("%v: expected output to contain %q, but got:\n%s", tt.args, tt.want, output.String())
+		}
+	}
+}
+
+func TestSuggestFlagIgnoresParentFlags(t *testing.T) {
+	// a parent flag given after the subcommand is rejected, and must not
+	// be suggested back
+	output := &bytes.Buffer{}
+	if err := newSuggestTestApp(output).Run([]string{"app", "install", "--config", "x"}); err == nil {
+		t.Fatal("expected usage error, but got none")
+	}
+	if strings.Contains(output.String(), "Did you mean") {
+		t.Errorf("expected no suggestion, but got:\n%s", output.String())
+	}
+}
+
+func TestSuggestFuncsAreOverridable(t *testing.T) {
+	output := &bytes.Buffer{}
+	app := newSuggestTestApp(output)
+	app.SuggestFlagFunc = func(flags []Flag, provided string, hideHelp bool) string {
+		return "--custom"
+	}
+	app.SuggestCommandFunc = func(commands []*Command, provided string) string {
+		return "custom"
+	}
+
+	_ = app.Run([]string{"app", "--nope"})
+	if !strings.Contains(output.String(), `Did you mean "--custom"?`) {
+		t.Errorf("expected custom flag suggestion, but got:\n%s", output.String())
+	}
+
+	err := app.Run([]string{"app", "nope"})
+	if err == nil || !strings.Contains(err.Error(), `Did you mean "custom"?`) {
+		t.Errorf("expected custom command suggestion, but got: %v", err)
+	}
+}
+
+func TestSuggestDisabledByDefault(t *testing.T) {
+	output := &bytes.Buffer{}
+	app := newSuggestTestApp(output)
+	app.Suggest = false
+
+	_ = app.Run([]string{"app", "--confg", "x"})
+	if strings.Contains(output.String(), "Did you mean") {
+		t.Errorf("expected no suggestion, but got:\n%s", output.String())
+	}
+}
@@ -0,0 +1,233 @@
+package cli
+
+// AppHelpTemplate is the text template for the Default help topic.
+// cli.go uses text/template to render templates. You can
+// render custom help text by setting this variable.
+var AppHelpTemplate = `NAME:
+   {{.Name}}{{if .Usage}} - {{.Usage}}{{end}}
+
+USAGE:
+   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}{{if .Commands}} command [command options]{{end}} {{if .ArgsUsage}}{{.ArgsUsage}}{{else}}[arguments...]{{end}}{{end}}{{if .Version}}{{if not .HideVersion}}
+
+VERSION:
+   {{.Version}}{{end}}{{end}}{{if .Description}}
+
+DESCRIPTION:
+   {{.Description}}{{end}}{{if len .Authors}}
+
+AUTHOR{{with $length := len .Authors}}{{if ne 1 $length}}S{{end}}{{end}}:
+   {{range $index, $author := .Authors}}{{if $index}}
+   {{end}}{{$author}}{{end}}{{end}}{{if .VisibleCommands}}
+
+COMMANDS:{{range .VisibleCategories}}{{if .Name}}
+
+   {{.Name}}:{{range .VisibleCommands}}
+     {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{else}}{{range .VisibleCommands}}
+   {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{end}}{{end}}{{end}}{{if .VisibleFlags}}
+
+GLOBAL OPTIONS:
+   {{range $index, $option := .VisibleFlags}}{{if $index}}
+   {{end}}{{$option}}{{end}}{{end}}{{if .Copyright}}
+
+COPYRIGHT:
+   {{.Copyright}}{{end}}
+`
+
+// CommandHelpTemplate is the text template for the command help topic.
+// cli.go uses text/template to render templates. You can
+// render custom help text by setting this variable.
+var CommandHelpTemplate = `NAME:
+   {{.HelpName}} - {{.Usage}}
+
+USAGE:
+   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}}{{if .VisibleFlags}} [command options]{{end}} {{if .ArgsUsage}}{{.ArgsUsage}}{{else}}[arguments...]{{end}}{{end}}{{if .Category}}
+
+CATEGORY:
+   {{.Category}}{{end}}{{if .Description}}
+
+DESCRIPTION:
+   {{.Description}}{{end}}{{if .Arguments}}
+
+ARGUMENTS:
+   {{range .Arguments}}{{.}}
+   {{end}}{{end}}{{if .VisibleFlags}}
+
+OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}{{end}}
+`
+
+// SubcommandHelpTemplate is the text template for the subcommand help topic.
+// cli.go uses text/template to render templates. You can
+// render custom help text by setting this variable.
+var SubcommandHelpTemplate = `NAME:
+   {{.HelpName}} - {{if .Description}}{{.Description}}{{else}}{{.Usage}}{{end}}
+
+USAGE:
+   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}} command{{if .VisibleFlags}} [command options]{{end}} {{if .ArgsUsage}}{{.ArgsUsage}}{{else}}[arguments...]{{end}}{{end}}
+
+COMMANDS:{{range .VisibleCategories}}{{if .Name}}
+
+   {{.Name}}:{{range .VisibleCommands}}
+     {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{else}}{{range .VisibleCommands}}
+   {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{end}}{{end}}{{if .VisibleFlags}}
+
+OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}{{end}}
+`
+
+// VersionTemplate is the text template for the version output printed by
+// the version flag. You can render a custom version text by setting this variable.
+var VersionTemplate = `{{.Name}} version {{.Version}}{{if not .Compiled.IsZero}}
+compiled: {{.Compiled.Format "2006-01-02T15:04:05Z07:00"}}{{end}}{{if len .Authors}}
+author{{with $length := len .Authors}}{{if ne 1 $length}}s{{end}}{{end}}: {{range $index, $author := .Authors}}{{if $index}}, {{end}}{{$author}}{{end}}{{end}}{{if .Copyright}}
+{{.Copyright}}{{end}}
+`
+
+// BashCompletionTemplate is the text template for the bash completion script
+// printed by the completion command. The script asks the app itself for the
+// candidates through the hidden completion flag.
+var BashCompletionTemplate = `#! /bin/bash
+
+_{{ .FuncName }}_bash_autocomplete() {
+  if [[ "${COMP_WORDS[0]}" != "source" ]]; then
+    local cur opts base
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ "$cur" == "-"* ]]; then
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} ${cur} --{{ .CompletionFlag }} )
+    else
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} --{{ .CompletionFlag }} )
+    fi
+    COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+    return 0
+  fi
+}
+
+complete -o bashdefault -o default -o nospace -F _{{ .FuncName }}_bash_autocomplete {{ .App.Name }}
+`
+
+// ZshCompletionTemplate is the text template for the zsh completion script
+// printed by the completion command.
+var ZshCompletionTemplate = `#compdef {{ .App.Name }}
+
+_{{ .FuncName }}_zsh_autocomplete() {
+
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} ${cur} --{{ .CompletionFlag }})}")
+  else
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} --{{ .CompletionFlag }})}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+
+  return
+}
+
+compdef _{{ .FuncName }}_zsh_autocomplete {{ .App.Name }}
+`
+
+// FishCompletionTemplate is the text template for the fish completion script
+// printed by the completion command. Unlike bash and zsh, the fish script is
+// generated from the whole command tree.
+var FishCompletionTemplate = `# {{ .App.Name }} fish shell completion
+
+function __fish_{{ .FuncName }}_no_subcommand --description 'Test if there has been any subcommand yet'
+    for i in (commandline -opc)
+        if contains -- $i{{ range $v := .AllCommands }} {{ $v }}{{ end }}
+            return 1
+        end
+    end
+    return 0
+end
+
+{{ range $v := .Completions }}{{ $v }}
+{{ end }}`
+
+// MarkdownDocTemplate is the text template for the markdown reference
+// generated by App.ToMarkdown.
+var MarkdownDocTemplate = `% {{ .App.Name }} 8
+
+# NAME
+
+{{ .App.Name }}{{ if .App.Usage }} - {{ .App.Usage }}{{ end }}
+
+# SYNOPSIS
+
+{{ .App.Name }}
+{{ if .SynopsisArgs }}
+` + "```" + `
+{{ range $v := .SynopsisArgs }}{{ $v }}
+{{ end }}` + "```" + `
+{{ end }}{{ if .App.Description }}
+# DESCRIPTION
+
+{{ .App.Description }}
+{{ end }}
+**Usage**:
+
+` + "```" + `
+{{ if .App.UsageText }}{{ .App.UsageText }}{{ else }}{{ .App.Name }} [GLOBAL OPTIONS] command [COMMAND OPTIONS] [ARGUMENTS...]{{ end }}
+` + "```" + `
+{{ if .GlobalFlags }}
+# GLOBAL OPTIONS
+{{ range $f := .GlobalFlags }}
+**{{ join $f.Names ", " }}**{{ if $f.TakesValue }}="{{ $f.Value }}"{{ end }}: {{ $f.Usage }}
+{{ end }}{{ end }}{{ if .Categories }}
+# COMMANDS
+{{ range $cat := .Categories }}{{ if $cat.Name }}
+## {{ $cat.Name }}
+{{ end }}{{ range $c := $cat.Commands }}
+{{ repeat "#" (add $c.Level 2) }}{{ if $cat.Name }}#{{ end }} {{ join $c.Names ", " }}
+
+{{ $c.Usage }}
+{{ if $c.Description }}
+{{ $c.Description }}
+{{ end }}{{ if $c.ArgsUsage }}
+**Usage**: ` + "`" + `{{ $.App.Name }} {{ $c.FullName }} [command options] {{ $c.ArgsUsage }}` + "`" + `
+{{ end }}{{ range $f := $c.Flags }}
+**{{ join $f.Names ", " }}**{{ if $f.TakesValue }}="{{ $f.Value }}"{{ end }}: {{ $f.Usage }}
+{{ end }}{{ end }}{{ end }}{{ end }}`
+
+// ManDocTemplate is the text template for the roff man page generated by
+// App.ToMan.
+var ManDocTemplate = `.TH "{{ upper .App.Name }}" "8"
+.SH NAME
+{{ roff .App.Name }}{{ if .App.Usage }} \- {{ roff .App.Usage }}{{ end }}
+.SH SYNOPSIS
+.B {{ roff .App.Name }}
+{{ range $v := .SynopsisArgs }}{{ roffOpt $v }}
+{{ end }}{{ if .App.Description }}.SH DESCRIPTION
+{{ roff .App.Description }}
+{{ end }}.PP
+.B Usage:
+.PP
+.nf
+{{ if .App.UsageText }}{{ roff .App.UsageText }}{{ else }}{{ roff .App.Name }} [GLOBAL OPTIONS] command [COMMAND OPTIONS] [ARGUMENTS...]{{ end }}
+.fi
+{{ if .GlobalFlags }}.SH GLOBAL OPTIONS
+{{ range $f := .GlobalFlags }}.TP
+\fB{{ roffOpt (join $f.Names ", ") }}\fP{{ if $f.TakesValue }}="{{ roff $f.Value }}"{{ end }}
+{{ if $f.Usage }}{{ roff $f.Usage }}
+{{ end }}{{ end }}{{ end }}{{ if .Categories }}.SH COMMANDS
+{{ range $cat := .Categories }}{{ range $c := $cat.Commands }}.SS {{ roff $c.FullName }}{{ if gt (len $c.Names) 1 }} ({{ roff (join $c.Names ", ") }}){{ end }}
+{{ if $cat.Name }}.I {{ roff $cat.Name }}
+.br
+{{ end }}{{ roff $c.Usage }}
+{{ if $c.Description }}.PP
+{{ roff $c.Description }}
+{{ end }}{{ if $c.ArgsUsage }}.PP
+.B Usage:
+{{ roff $.App.Name }} {{ roff $c.FullName }} [command options] {{ roff $c.ArgsUsage }}
+{{ end }}{{ range $f := $c.Flags }}.TP
+\fB{{ roffOpt (join $f.Names ", ") }}\fP{{ if $f.TakesValue }}="{{ roff $f.Value }}"{{ end }}
+{{ if $f.Usage }}{{ roff $f.Usage }}
+{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}`
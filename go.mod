@@ -1,6 +1,7 @@
 module github.com/gy-kim/golang-daily-practice
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/DATA-DOG/go-sqlmock v1.3.3
 	github.com/PacktPublishing/Hands-On-Dependency-Injection-in-Go v0.0.0-20190418235907-40e102283025
 	github.com/Shopify/sarama v1.23.1